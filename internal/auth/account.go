@@ -0,0 +1,52 @@
+package auth
+
+import "strings"
+
+// AccountIdentifiers splits a Snowflake account identifier into the form
+// used in JWT claims and the form used in the URL host. Supported inputs:
+//
+//	xy12345                 legacy locator      -> XY12345,      xy12345
+//	xy12345.us-east-1       locator with region -> XY12345,      xy12345.us-east-1
+//	xy12345.east-us-2.azure                     -> XY12345,      xy12345.east-us-2.azure
+//	myorg-myaccount         org-account         -> MYORG-MYACCOUNT, myorg-myaccount
+//	myorg.myaccount         org-account (SQL)   -> MYORG-MYACCOUNT, myorg-myaccount
+//
+// A trailing ".snowflakecomputing.com" (with or without ".privatelink") is
+// ignored, so a copied hostname also works.
+func AccountIdentifiers(account string) (jwtAccount, hostAccount string) {
+	account = strings.TrimSpace(account)
+	lower := strings.ToLower(account)
+	for _, suffix := range []string{".privatelink.snowflakecomputing.com", ".snowflakecomputing.com"} {
+		if strings.HasSuffix(lower, suffix) {
+			account = account[:len(account)-len(suffix)]
+			break
+		}
+	}
+
+	name, rest, dotted := strings.Cut(account, ".")
+	switch {
+	case !dotted:
+		return strings.ToUpper(account), account
+	case isAccountName(rest):
+		// orgname.accountname: hosts and JWTs both use a hyphen.
+		host := name + "-" + rest
+		return strings.ToUpper(host), host
+	default:
+		// locator.region[.cloud]: the JWT uses only the locator.
+		return strings.ToUpper(name), account
+	}
+}
+
+// isAccountName reports whether s is a valid account name (letters, digits
+// and underscores), as opposed to a region such as "us-east-1".
+func isAccountName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
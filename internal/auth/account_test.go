@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestAccountIdentifiers(t *testing.T) {
+	tests := []struct {
+		in, jwt, host string
+	}{
+		{"xy12345", "XY12345", "xy12345"},
+		{"xy12345.us-east-1", "XY12345", "xy12345.us-east-1"},
+		{"xy12345.east-us-2.azure", "XY12345", "xy12345.east-us-2.azure"},
+		{"myorg-myaccount", "MYORG-MYACCOUNT", "myorg-myaccount"},
+		{"myorg.my_account", "MYORG-MY_ACCOUNT", "myorg-my_account"},
+		{"myorg-myaccount.snowflakecomputing.com", "MYORG-MYACCOUNT", "myorg-myaccount"},
+		{"xy12345.us-west-2.privatelink.snowflakecomputing.com", "XY12345", "xy12345.us-west-2"},
+	}
+	for _, tt := range tests {
+		jwtAccount, host := AccountIdentifiers(tt.in)
+		if jwtAccount != tt.jwt || host != tt.host {
+			t.Errorf("%s: expected (%s, %s), got (%s, %s)", tt.in, tt.jwt, tt.host, jwtAccount, host)
+		}
+	}
+}
+
+func TestGenerateJWT_RegionQualifiedAccount(t *testing.T) {
+	signed, err := GenerateJWT(TokenConfig{
+		Account:     "xy12345.us-east-1",
+		User:        "jdoe",
+		PrivateKey:  readTestdata(t, "rsa_key.p8"),
+		ExpireAfter: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(signed, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "XY12345.JDOE" {
+		t.Errorf("unexpected subject: %s", claims.Subject)
+	}
+}
@@ -1,13 +1,15 @@
 package auth
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -17,60 +19,129 @@ import (
 type TokenConfig struct {
 	Account     string // e.g., CXEEZLW-JQB53549
 	User        string // e.g., VJAIN27
-	PrivateKey  []byte // PEM-encoded private key (PKCS8)
-	PublicKey   []byte // PEM-encoded public key (used for fingerprint)
+	PrivateKey  []byte // PEM-encoded RSA or ECDSA private key (PKCS8)
+	PublicKey   []byte // Optional: PEM-encoded public key; derived from PrivateKey when empty
+	Passphrase  []byte // Optional: decrypts an "ENCRYPTED PRIVATE KEY" block
 	ExpireAfter time.Duration
+
+	// IssuedAtSkew backdates the iat claim to tolerate clock drift between
+	// this host and Snowflake. ExpiresAt is still now + ExpireAfter.
+	IssuedAtSkew time.Duration
+	// SetNotBefore also sets nbf to the (skewed) issued-at time.
+	SetNotBefore bool
+
+	// Audience overrides the aud claim, "snowflake" by default.
+	Audience string
+	// Issuer, when set, builds the iss claim in place of the default
+	// ACCOUNT.USER.SHA256:fingerprint from its upper-cased parts.
+	Issuer func(account, user, fingerprint string) string
 }
 
-// GenerateJWT returns a Snowflake-compatible JWT token.
+// GenerateJWT returns a Snowflake-compatible JWT token. Callers signing
+// repeatedly should use NewSigner to parse the key once.
 func GenerateJWT(cfg TokenConfig) (string, error) {
-	privKey, err := parsePrivateKey(cfg.PrivateKey)
+	s, err := NewSigner(cfg)
 	if err != nil {
 		return "", err
 	}
+	return s.Token()
+}
+
+// timeNow is swapped out in tests.
+var timeNow = time.Now
+
+// MaxExpireAfter is the longest JWT lifetime Snowflake accepts.
+const MaxExpireAfter = time.Hour
 
-	fp, err := fingerprint(cfg.PublicKey)
+// ValidateKeyPair checks that the private key (and public key, if given)
+// parse, are of a supported type and belong together, without signing
+// anything.
+func ValidateKeyPair(privateKey, publicKey, passphrase []byte) error {
+	key, err := parsePrivateKey(privateKey, passphrase)
 	if err != nil {
-		return "", fmt.Errorf("fingerprint generation failed: %w", err)
+		return fmt.Errorf("invalid private key: %w", err)
 	}
-
-	account := normalizeAccount(cfg.Account)
-	user := strings.ToUpper(cfg.User)
-	subject := fmt.Sprintf("%s.%s", account, user)
-	issuer := fmt.Sprintf("%s.%s.%s", account, user, fp)
-
-	now := time.Now().UTC()
-	claims := jwt.RegisteredClaims{
-		Issuer:    issuer,
-		Subject:   subject,
-		Audience:  jwt.ClaimStrings{"snowflake"},
-		IssuedAt:  jwt.NewNumericDate(now),
-		ExpiresAt: jwt.NewNumericDate(now.Add(cfg.ExpireAfter)),
+	if len(publicKey) > 0 {
+		fp, err := fingerprint(publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid public key: %w", err)
+		}
+		derived, err := publicKeyFingerprint(key.Public())
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		if fp != derived {
+			return fmt.Errorf("public key does not match private key")
+		}
 	}
+	return nil
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
-	signed, err := token.SignedString(privKey)
+// PublicKeyPEM derives the PEM-encoded (PKIX) public key of a private key.
+func PublicKeyPEM(privateKey, passphrase []byte) ([]byte, error) {
+	key, err := parsePrivateKey(privateKey, passphrase)
 	if err != nil {
-		return "", fmt.Errorf("JWT signing failed: %w", err)
+		return nil, fmt.Errorf("invalid private key: %w", err)
 	}
-	return signed, nil
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
 }
 
-// parsePrivateKey parses a PEM-encoded PKCS#8 RSA key.
-func parsePrivateKey(pemBytes []byte) (*rsa.PrivateKey, error) {
+// parsePrivateKey parses a PEM-encoded PKCS#8 RSA or ECDSA key, decrypting it
+// with passphrase when the block is encrypted. A passphrase supplied for an
+// unencrypted key is ignored.
+func parsePrivateKey(pemBytes, passphrase []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(pemBytes)
 	if block == nil {
 		return nil, fmt.Errorf("invalid PEM format for private key")
 	}
-	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+
+	der := block.Bytes
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		if len(passphrase) == 0 {
+			return nil, fmt.Errorf("private key is encrypted but no passphrase was provided")
+		}
+		var err error
+		der, err = decryptPKCS8(der, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
 	if err != nil {
 		return nil, err
 	}
-	rsaKey, ok := key.(*rsa.PrivateKey)
-	if !ok {
-		return nil, fmt.Errorf("not an RSA private key")
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T (expected RSA or ECDSA)", key)
+	}
+}
+
+// signingMethod picks the JWT algorithm matching the key type and curve.
+func signingMethod(key crypto.Signer) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, fmt.Errorf("unsupported ECDSA curve %s", k.Curve.Params().Name)
 	}
-	return rsaKey, nil
+	return nil, fmt.Errorf("unsupported private key type %T", key)
 }
 
 // fingerprint computes the SHA256 fingerprint of a PEM-encoded public key.
@@ -83,7 +154,13 @@ func fingerprint(pubPEM []byte) (string, error) {
 	return "SHA256:" + base64.StdEncoding.EncodeToString(hash[:]), nil
 }
 
-// normalizeAccount ensures uppercase and replaces periods with hyphens.
-func normalizeAccount(account string) string {
-	return strings.ToUpper(strings.ReplaceAll(account, ".", "-"))
+// publicKeyFingerprint computes the SHA256 fingerprint of a public key's
+// PKIX DER encoding, matching fingerprint of its PEM form.
+func publicKeyFingerprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(der)
+	return "SHA256:" + base64.StdEncoding.EncodeToString(hash[:]), nil
 }
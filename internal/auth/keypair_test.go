@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return b
+}
+
+func TestParsePrivateKey_Encrypted(t *testing.T) {
+	plain, err := parsePrivateKey(readTestdata(t, "rsa_key.p8"), nil)
+	if err != nil {
+		t.Fatalf("failed to parse unencrypted key: %v", err)
+	}
+
+	decrypted, err := parsePrivateKey(readTestdata(t, "rsa_key_encrypted.p8"), []byte("test-passphrase"))
+	if err != nil {
+		t.Fatalf("failed to parse encrypted key: %v", err)
+	}
+	if !plain.(*rsa.PrivateKey).Equal(decrypted) {
+		t.Error("decrypted key does not match the unencrypted key")
+	}
+}
+
+// rsa_key_aes256.p8 was generated with
+// `openssl pkcs8 -topk8 -v2 aes-256-cbc -in rsa_key.p8 -passout pass:openssl-passphrase`.
+func TestParsePrivateKey_OpenSSLAES256(t *testing.T) {
+	plain, err := parsePrivateKey(readTestdata(t, "rsa_key.p8"), nil)
+	if err != nil {
+		t.Fatalf("failed to parse unencrypted key: %v", err)
+	}
+
+	decrypted, err := parsePrivateKey(readTestdata(t, "rsa_key_aes256.p8"), []byte("openssl-passphrase"))
+	if err != nil {
+		t.Fatalf("failed to parse encrypted key: %v", err)
+	}
+	if !plain.(*rsa.PrivateKey).Equal(decrypted) {
+		t.Error("decrypted key does not match the unencrypted key")
+	}
+}
+
+func TestParsePrivateKey_EncryptedWithoutPassphrase(t *testing.T) {
+	_, err := parsePrivateKey(readTestdata(t, "rsa_key_encrypted.p8"), nil)
+	if err == nil || !strings.Contains(err.Error(), "no passphrase") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParsePrivateKey_WrongPassphrase(t *testing.T) {
+	_, err := parsePrivateKey(readTestdata(t, "rsa_key_encrypted.p8"), []byte("wrong"))
+	if err == nil {
+		t.Error("expected an error for a wrong passphrase")
+	}
+}
+
+func TestParsePrivateKey_PassphraseIgnoredForPlainKey(t *testing.T) {
+	if _, err := parsePrivateKey(readTestdata(t, "rsa_key.p8"), []byte("unused")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGenerateJWT_EncryptedKey(t *testing.T) {
+	token, err := GenerateJWT(TokenConfig{
+		Account:    "testacct",
+		User:       "testuser",
+		PrivateKey: readTestdata(t, "rsa_key_encrypted.p8"),
+		PublicKey:  readTestdata(t, "rsa_key.pub"),
+		Passphrase: []byte("test-passphrase"),
+	})
+	if err != nil || token == "" {
+		t.Fatalf("expected token, got err: %v", err)
+	}
+}
+
+// generateKeyPair returns PEM-encoded PKCS#8 private and PKIX public keys.
+func generateKeyPair(t *testing.T, key crypto.Signer) (priv, pub []byte) {
+	t.Helper()
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+}
+
+func TestGenerateJWT_KeyTypes(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+	}{
+		{"RSA", rsaKey, "RS256"},
+		{"EC P-256", p256, "ES256"},
+		{"EC P-384", p384, "ES384"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			priv, pub := generateKeyPair(t, tt.key)
+			signed, err := GenerateJWT(TokenConfig{
+				Account:     "testacct",
+				User:        "testuser",
+				PrivateKey:  priv,
+				PublicKey:   pub,
+				ExpireAfter: time.Minute,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			token, err := jwt.Parse(signed, func(tok *jwt.Token) (any, error) {
+				return tt.key.Public(), nil
+			})
+			if err != nil || !token.Valid {
+				t.Fatalf("token failed verification: %v", err)
+			}
+			if token.Method.Alg() != tt.alg {
+				t.Errorf("expected %s, got %s", tt.alg, token.Method.Alg())
+			}
+		})
+	}
+}
+
+func TestGenerateJWT_DerivesFingerprint(t *testing.T) {
+	priv := readTestdata(t, "rsa_key.p8")
+	issuer := func(pub []byte) string {
+		t.Helper()
+		signed, err := GenerateJWT(TokenConfig{
+			Account:     "testacct",
+			User:        "testuser",
+			PrivateKey:  priv,
+			PublicKey:   pub,
+			ExpireAfter: time.Minute,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var claims jwt.RegisteredClaims
+		if _, _, err := jwt.NewParser().ParseUnverified(signed, &claims); err != nil {
+			t.Fatalf("failed to parse token: %v", err)
+		}
+		return claims.Issuer
+	}
+
+	derived := issuer(nil)
+	explicit := issuer(readTestdata(t, "rsa_key.pub"))
+	if derived != explicit || !strings.Contains(derived, ".SHA256:") {
+		t.Errorf("derived issuer %q does not match explicit %q", derived, explicit)
+	}
+}
+
+func TestValidateKeyPair_Mismatch(t *testing.T) {
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPub := generateKeyPair(t, other)
+
+	priv := readTestdata(t, "rsa_key.p8")
+	if err := ValidateKeyPair(priv, readTestdata(t, "rsa_key.pub"), nil); err != nil {
+		t.Errorf("unexpected error for matching pair: %v", err)
+	}
+	if err := ValidateKeyPair(priv, nil, nil); err != nil {
+		t.Errorf("unexpected error without public key: %v", err)
+	}
+	if err := ValidateKeyPair(priv, otherPub, nil); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("expected mismatch error, got: %v", err)
+	}
+}
+
+func TestGenerateJWT_IssuedAtSkew(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	signed, err := GenerateJWT(TokenConfig{
+		Account:      "testacct",
+		User:         "testuser",
+		PrivateKey:   readTestdata(t, "rsa_key.p8"),
+		ExpireAfter:  10 * time.Minute,
+		IssuedAtSkew: 30 * time.Second,
+		SetNotBefore: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(signed, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(-30 * time.Second); !claims.IssuedAt.Time.Equal(want) {
+		t.Errorf("expected iat %v, got %v", want, claims.IssuedAt.Time)
+	}
+	if claims.NotBefore == nil || !claims.NotBefore.Time.Equal(claims.IssuedAt.Time) {
+		t.Errorf("expected nbf equal to iat, got %v", claims.NotBefore)
+	}
+	if want := now.Add(10 * time.Minute); !claims.ExpiresAt.Time.Equal(want) {
+		t.Errorf("expected exp %v, got %v", want, claims.ExpiresAt.Time)
+	}
+}
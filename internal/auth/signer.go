@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"crypto"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// defaultAudience is the aud claim Snowflake expects unless overridden.
+const defaultAudience = "snowflake"
+
+// Signer holds a parsed private key and the JWT identity derived from a
+// TokenConfig, so tokens can be signed repeatedly without re-parsing PEM or
+// recomputing the key fingerprint. It is safe for concurrent use.
+type Signer struct {
+	key    crypto.Signer
+	method jwt.SigningMethod
+
+	subject      string
+	issuer       string
+	audience     string
+	expireAfter  time.Duration
+	issuedAtSkew time.Duration
+	setNotBefore bool
+}
+
+// NewSigner parses cfg's RSA or ECDSA private key and derives the subject
+// and issuer claims.
+func NewSigner(cfg TokenConfig) (*Signer, error) {
+	privKey, err := parsePrivateKey(cfg.PrivateKey, cfg.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	var fp string
+	if len(cfg.PublicKey) > 0 {
+		fp, err = fingerprint(cfg.PublicKey)
+	} else {
+		fp, err = publicKeyFingerprint(privKey.Public())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fingerprint generation failed: %w", err)
+	}
+
+	method, err := signingMethod(privKey)
+	if err != nil {
+		return nil, err
+	}
+
+	account, _ := AccountIdentifiers(cfg.Account)
+	user := strings.ToUpper(cfg.User)
+	if account == "" || user == "" {
+		return nil, fmt.Errorf("account and user are required for the JWT subject")
+	}
+	issuer := fmt.Sprintf("%s.%s.%s", account, user, fp)
+	if cfg.Issuer != nil {
+		if issuer = cfg.Issuer(account, user, fp); strings.TrimSpace(issuer) == "" {
+			return nil, fmt.Errorf("issuer override produced an empty issuer")
+		}
+	}
+	audience := defaultAudience
+	if cfg.Audience != "" {
+		if audience = strings.TrimSpace(cfg.Audience); audience == "" {
+			return nil, fmt.Errorf("audience may not be blank")
+		}
+	}
+	return &Signer{
+		key:          privKey,
+		method:       method,
+		subject:      fmt.Sprintf("%s.%s", account, user),
+		issuer:       issuer,
+		audience:     audience,
+		expireAfter:  cfg.ExpireAfter,
+		issuedAtSkew: cfg.IssuedAtSkew,
+		setNotBefore: cfg.SetNotBefore,
+	}, nil
+}
+
+// Claims returns the standard Snowflake claims for a token issued now.
+func (s *Signer) Claims() jwt.RegisteredClaims {
+	now := timeNow().UTC()
+	issuedAt := now.Add(-s.issuedAtSkew)
+	claims := jwt.RegisteredClaims{
+		Issuer:    s.issuer,
+		Subject:   s.subject,
+		Audience:  jwt.ClaimStrings{s.audience},
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
+		ExpiresAt: jwt.NewNumericDate(now.Add(s.expireAfter)),
+	}
+	if s.setNotBefore {
+		claims.NotBefore = jwt.NewNumericDate(issuedAt)
+	}
+	return claims
+}
+
+// Sign signs claims with the parsed key.
+func (s *Signer) Sign(claims jwt.Claims) (string, error) {
+	signed, err := jwt.NewWithClaims(s.method, claims).SignedString(s.key)
+	if err != nil {
+		return "", fmt.Errorf("JWT signing failed: %w", err)
+	}
+	return signed, nil
+}
+
+// Token signs the standard claims; see Claims.
+func (s *Signer) Token() (string, error) {
+	return s.Sign(s.Claims())
+}
+
+// DecodeClaims parses the registered claims of token without verifying its
+// signature, for troubleshooting only.
+func DecodeClaims(token string) (*jwt.RegisteredClaims, error) {
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return nil, fmt.Errorf("decode JWT: %w", err)
+	}
+	return &claims, nil
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestSigner_Reuse(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := generateKeyPair(t, key)
+
+	s, err := NewSigner(TokenConfig{Account: "testacct", User: "testuser", PrivateKey: priv, ExpireAfter: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		signed, err := s.Token()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var claims jwt.RegisteredClaims
+		if _, err := jwt.ParseWithClaims(signed, &claims, func(*jwt.Token) (any, error) {
+			return key.Public(), nil
+		}); err != nil {
+			t.Fatalf("token failed verification: %v", err)
+		}
+		if claims.Subject != "TESTACCT.TESTUSER" {
+			t.Errorf("unexpected subject %q", claims.Subject)
+		}
+	}
+}
+
+func TestSigner_SignCustomClaims(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := generateKeyPair(t, key)
+
+	s, err := NewSigner(TokenConfig{Account: "testacct", User: "testuser", PrivateKey: priv, ExpireAfter: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims := s.Claims()
+	claims.ID = "jti-1"
+	signed, err := s.Sign(claims)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got jwt.RegisteredClaims
+	if _, err := jwt.ParseWithClaims(signed, &got, func(*jwt.Token) (any, error) {
+		return key.Public(), nil
+	}); err != nil || got.ID != "jti-1" {
+		t.Fatalf("unexpected claims %+v: %v", got, err)
+	}
+}
+
+func TestNewSigner_InvalidKey(t *testing.T) {
+	if _, err := NewSigner(TokenConfig{PrivateKey: []byte("not pem")}); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+}
+
+func TestSigner_AudienceAndIssuerOverrides(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := generateKeyPair(t, key)
+
+	s, err := NewSigner(TokenConfig{
+		Account:     "testacct",
+		User:        "testuser",
+		PrivateKey:  priv,
+		ExpireAfter: time.Minute,
+		Audience:    "snowflake-gov",
+		Issuer: func(account, user, fp string) string {
+			return account + "." + user + ".custom." + fp
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signed, err := s.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var claims jwt.RegisteredClaims
+	if _, err := jwt.ParseWithClaims(signed, &claims, func(*jwt.Token) (any, error) {
+		return key.Public(), nil
+	}, jwt.WithAudience("snowflake-gov")); err != nil {
+		t.Fatalf("token failed verification: %v", err)
+	}
+	if !strings.HasPrefix(claims.Issuer, "TESTACCT.TESTUSER.custom.SHA256:") {
+		t.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if claims.Subject != "TESTACCT.TESTUSER" {
+		t.Errorf("unexpected subject %q", claims.Subject)
+	}
+
+	s, err = NewSigner(TokenConfig{Account: "testacct", User: "testuser", PrivateKey: priv, ExpireAfter: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if aud := s.Claims().Audience; len(aud) != 1 || aud[0] != "snowflake" {
+		t.Errorf("expected the default audience, got %v", aud)
+	}
+}
+
+func TestSigner_InvalidOverrides(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := generateKeyPair(t, key)
+
+	tests := []struct {
+		name string
+		cfg  TokenConfig
+		want string
+	}{
+		{"empty issuer", TokenConfig{Account: "a", User: "u", Issuer: func(string, string, string) string { return " " }}, "empty issuer"},
+		{"blank audience", TokenConfig{Account: "a", User: "u", Audience: "  "}, "audience may not be blank"},
+		{"empty subject", TokenConfig{Account: "a"}, "account and user are required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.PrivateKey = priv
+			if _, err := NewSigner(tt.cfg); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestDecodeClaims(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	priv, _ := generateKeyPair(t, key)
+	s, err := NewSigner(TokenConfig{Account: "testacct", User: "testuser", PrivateKey: priv, ExpireAfter: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signed, err := s.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := DecodeClaims(signed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "TESTACCT.TESTUSER" || !strings.HasPrefix(claims.Issuer, "TESTACCT.TESTUSER.SHA256:") {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if _, err := DecodeClaims("not-a-jwt"); err == nil {
+		t.Error("expected an error for a malformed token")
+	}
+}
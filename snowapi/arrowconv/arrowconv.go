@@ -0,0 +1,179 @@
+// Package arrowconv converts Snowflake SQL API results to Apache Arrow
+// records, for handing to DataFrame libraries:
+//
+//	resp, err := client.ExecuteAndWait("SELECT * FROM t", nil)
+//	...
+//	rec, err := arrowconv.FromResponse(memory.DefaultAllocator, resp)
+//	defer rec.Release()
+//
+// The SQL API only returns JSON, so values are converted after decoding;
+// nothing travels as Arrow on the wire. The Arrow dependency is limited to
+// programs that import this package.
+package arrowconv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+var timestampUTC = &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}
+
+// DataType maps a Snowflake column to its Arrow type: FIXED with scale 0 to
+// int64 and with a scale, like REAL, to float64; BOOLEAN to bool; DATE to
+// date32; TIME to time64[ns]; TIMESTAMP_NTZ to a timestamp[ns] without a
+// zone and TIMESTAMP_LTZ/TZ to timestamp[ns, UTC]; BINARY to binary; and
+// everything else, including VARIANT, OBJECT and ARRAY as JSON text, to
+// string.
+func DataType(col snowapi.ColumnMeta) arrow.DataType {
+	switch strings.ToLower(col.Type) {
+	case "fixed":
+		if col.Scale == nil || *col.Scale == 0 {
+			return arrow.PrimitiveTypes.Int64
+		}
+		return arrow.PrimitiveTypes.Float64
+	case "real":
+		return arrow.PrimitiveTypes.Float64
+	case "boolean":
+		return arrow.FixedWidthTypes.Boolean
+	case "date":
+		return arrow.FixedWidthTypes.Date32
+	case "time":
+		return arrow.FixedWidthTypes.Time64ns
+	case "timestamp_ntz":
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}
+	case "timestamp_ltz", "timestamp_tz":
+		return timestampUTC
+	case "binary":
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// Schema returns the Arrow schema for a result's columns.
+func Schema(cols []snowapi.ColumnMeta) *arrow.Schema {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		fields[i] = arrow.Field{Name: col.Name, Type: DataType(col), Nullable: col.Nullable}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// FromResponse converts the rows in resp to a record. For a multi-partition
+// result, convert each partition's rows with NewRecord and the columns of
+// the first response.
+func FromResponse(mem memory.Allocator, resp *snowapi.QueryResponse) (arrow.Record, error) {
+	return NewRecord(mem, resp.Columns(), resp.Data)
+}
+
+// NewRecord converts rows described by cols to a record, allocating from
+// mem (memory.DefaultAllocator when nil). NULLs become Arrow nulls. The
+// caller must Release the record.
+func NewRecord(mem memory.Allocator, cols []snowapi.ColumnMeta, rows [][]any) (arrow.Record, error) {
+	if mem == nil {
+		mem = memory.DefaultAllocator
+	}
+	b := array.NewRecordBuilder(mem, Schema(cols))
+	defer b.Release()
+
+	for i, col := range cols {
+		fb := b.Field(i)
+		fb.Reserve(len(rows))
+		for r, row := range rows {
+			var raw any
+			if i < len(row) {
+				raw = row[i]
+			}
+			if err := appendValue(fb, col, raw); err != nil {
+				return nil, fmt.Errorf("row %d, column %s: %w", r, col.Name, err)
+			}
+		}
+	}
+	return b.NewRecord(), nil
+}
+
+func appendValue(fb array.Builder, col snowapi.ColumnMeta, raw any) error {
+	if raw == nil {
+		fb.AppendNull()
+		return nil
+	}
+	if sb, ok := fb.(*array.StringBuilder); ok {
+		// Keep text, including VARIANT JSON, as Snowflake sent it.
+		if s, ok := raw.(string); ok {
+			sb.Append(s)
+			return nil
+		}
+		sb.Append(fmt.Sprint(raw))
+		return nil
+	}
+
+	v, err := snowapi.DecodeValue(raw, col)
+	if err != nil {
+		return err
+	}
+	switch b := fb.(type) {
+	case *array.Int64Builder:
+		switch n := v.(type) {
+		case int64:
+			b.Append(n)
+		case snowapi.Decimal:
+			i, ok := n.Int64()
+			if !ok {
+				return fmt.Errorf("value %s overflows int64", n)
+			}
+			b.Append(i)
+		default:
+			return fmt.Errorf("unexpected %T value", v)
+		}
+	case *array.Float64Builder:
+		switch f := v.(type) {
+		case float64:
+			b.Append(f)
+		case snowapi.Decimal:
+			b.Append(f.Float64())
+		default:
+			return fmt.Errorf("unexpected %T value", v)
+		}
+	case *array.BooleanBuilder:
+		t, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected %T value", v)
+		}
+		b.Append(t)
+	case *array.BinaryBuilder:
+		p, ok := v.([]byte)
+		if !ok {
+			return fmt.Errorf("unexpected %T value", v)
+		}
+		b.Append(p)
+	case *array.Date32Builder:
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected %T value", v)
+		}
+		b.Append(arrow.Date32FromTime(t))
+	case *array.Time64Builder:
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected %T value", v)
+		}
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		b.Append(arrow.Time64(t.Sub(midnight).Nanoseconds()))
+	case *array.TimestampBuilder:
+		t, ok := v.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected %T value", v)
+		}
+		b.Append(arrow.Timestamp(t.UnixNano()))
+	default:
+		return fmt.Errorf("unsupported builder %T", fb)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package arrowconv
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+func intPtr(i int) *int { return &i }
+
+func TestFromResponse(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	resp := &snowapi.QueryResponse{
+		ResultSetMetaData: snowapi.ResultSetMetaData{RowType: []snowapi.ColumnMeta{
+			{Name: "ID", Type: "fixed", Scale: intPtr(0)},
+			{Name: "PRICE", Type: "fixed", Scale: intPtr(2), Nullable: true},
+			{Name: "ACTIVE", Type: "boolean", Nullable: true},
+			{Name: "CREATED", Type: "timestamp_ntz", Nullable: true},
+			{Name: "NAME", Type: "text", Nullable: true},
+			{Name: "ATTRS", Type: "variant", Nullable: true},
+			{Name: "DAY", Type: "date"},
+		}},
+		Data: [][]any{
+			{"1", "9.50", "true", "1710498645.123000000", "alice", `{"k":1}`, "19797"},
+			{"2", nil, nil, nil, nil, nil, "0"},
+		},
+	}
+
+	rec, err := FromResponse(mem, resp)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rec.Release()
+
+	if rec.NumRows() != 2 || rec.NumCols() != 7 {
+		t.Fatalf("unexpected shape %dx%d", rec.NumRows(), rec.NumCols())
+	}
+	wantTypes := []arrow.DataType{
+		arrow.PrimitiveTypes.Int64, arrow.PrimitiveTypes.Float64, arrow.FixedWidthTypes.Boolean,
+		&arrow.TimestampType{Unit: arrow.Nanosecond}, arrow.BinaryTypes.String, arrow.BinaryTypes.String,
+		arrow.FixedWidthTypes.Date32,
+	}
+	for i, want := range wantTypes {
+		if got := rec.Schema().Field(i).Type; !arrow.TypeEqual(got, want) {
+			t.Errorf("column %d: type %v, want %v", i, got, want)
+		}
+	}
+
+	if v := rec.Column(0).(*array.Int64).Value(1); v != 2 {
+		t.Errorf("ID = %d", v)
+	}
+	if v := rec.Column(1).(*array.Float64).Value(0); v != 9.5 {
+		t.Errorf("PRICE = %v", v)
+	}
+	if !rec.Column(2).(*array.Boolean).Value(0) {
+		t.Error("ACTIVE = false")
+	}
+	want := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)
+	if v := rec.Column(3).(*array.Timestamp).Value(0); v.ToTime(arrow.Nanosecond) != want {
+		t.Errorf("CREATED = %v, want %v", v.ToTime(arrow.Nanosecond), want)
+	}
+	if v := rec.Column(5).(*array.String).Value(0); v != `{"k":1}` {
+		t.Errorf("ATTRS = %q", v)
+	}
+	if v := rec.Column(6).(*array.Date32).Value(0).ToTime(); !v.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("DAY = %v", v)
+	}
+	for i := 1; i <= 5; i++ {
+		if !rec.Column(i).IsNull(1) {
+			t.Errorf("column %d: expected NULL in row 1", i)
+		}
+	}
+}
+
+func TestNewRecord_InvalidValue(t *testing.T) {
+	cols := []snowapi.ColumnMeta{{Name: "ID", Type: "fixed"}}
+	_, err := NewRecord(nil, cols, [][]any{{"1"}, {"x"}})
+	if err == nil || !strings.Contains(err.Error(), "row 1, column ID") {
+		t.Errorf("expected an error naming the cell, got %v", err)
+	}
+}
+
+func TestNewRecord_HighPrecisionInteger(t *testing.T) {
+	cols := []snowapi.ColumnMeta{{Name: "ID", Type: "fixed", Scale: intPtr(0), Precision: intPtr(38)}}
+	rec, err := NewRecord(nil, cols, [][]any{{"42"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rec.Release()
+	if got := rec.Column(0).(*array.Int64).Value(0); got != 42 {
+		t.Errorf("expected 42, got %d", got)
+	}
+
+	if _, err := NewRecord(nil, cols, [][]any{{"99999999999999999999"}}); err == nil || !strings.Contains(err.Error(), "overflows int64") {
+		t.Errorf("expected an overflow error, got %v", err)
+	}
+}
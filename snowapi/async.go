@@ -0,0 +1,110 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// AsyncHandle identifies a statement submitted with ExecuteAsync and is bound
+// to the client that submitted it.
+type AsyncHandle struct {
+	Handle    string // statement handle
+	StatusURL string // statementStatusUrl reported by Snowflake
+
+	client *Client
+}
+
+// ExecuteAsync submits a statement without waiting for it to run and returns
+// a handle for polling, waiting on or cancelling it.
+func (c *Client) ExecuteAsync(statement string, opts *RequestOptions) (*AsyncHandle, error) {
+	return c.ExecuteAsyncContext(context.Background(), statement, opts)
+}
+
+// ExecuteAsyncContext is like ExecuteAsync but honors ctx for the submission.
+func (c *Client) ExecuteAsyncContext(ctx context.Context, statement string, opts *RequestOptions) (*AsyncHandle, error) {
+	resp, err := c.ExecuteContext(ctx, statement, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatementHandle == "" {
+		return nil, errors.New("async submission returned no statement handle")
+	}
+	return &AsyncHandle{
+		Handle:    resp.StatementHandle,
+		StatusURL: resp.StatementStatusURL,
+		client:    c,
+	}, nil
+}
+
+// Poll checks the statement's status once; see Client.Poll.
+func (h *AsyncHandle) Poll() (*QueryResponse, int, error) {
+	return h.PollContext(context.Background())
+}
+
+// PollContext is like Poll but honors ctx.
+func (h *AsyncHandle) PollContext(ctx context.Context) (*QueryResponse, int, error) {
+	return h.client.PollContext(ctx, h.Handle, 0)
+}
+
+// Wait polls until the statement completes; see Client.WaitUntilComplete.
+func (h *AsyncHandle) Wait(interval time.Duration, maxRetries int) (*QueryResponse, error) {
+	return h.WaitContext(context.Background(), interval, maxRetries)
+}
+
+// WaitContext is like Wait but stops as soon as ctx is done.
+func (h *AsyncHandle) WaitContext(ctx context.Context, interval time.Duration, maxRetries int) (*QueryResponse, error) {
+	return h.client.WaitUntilCompleteContext(ctx, h.Handle, interval, maxRetries)
+}
+
+// Cancel aborts the statement.
+func (h *AsyncHandle) Cancel() error {
+	return h.CancelContext(context.Background())
+}
+
+// CancelContext is like Cancel but honors ctx.
+func (h *AsyncHandle) CancelContext(ctx context.Context) error {
+	return h.client.CancelContext(ctx, h.Handle)
+}
+
+// WaitForAll polls every handle concurrently per strategy until each
+// statement completes, fails or ctx is done. It returns the final response
+// of each statement that completed, keyed by handle, and the joined errors
+// of the rest, each naming its handle. On cancellation the statements keep
+// running; only the polling stops.
+func (c *Client) WaitForAll(ctx context.Context, handles []string, strategy PollStrategy) (map[string]*QueryResponse, error) {
+	strategy = strategy.withDefaults()
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		results = make(map[string]*QueryResponse, len(handles))
+		errs    = make([]error, len(handles))
+		seen    = make(map[string]bool, len(handles))
+	)
+	for i, handle := range handles {
+		if seen[handle] {
+			continue
+		}
+		seen[handle] = true
+
+		wg.Add(1)
+		go func(i int, handle string) {
+			defer wg.Done()
+			resp, err := c.waitUntilComplete(ctx, handle, strategy, math.MaxInt32)
+			if err != nil {
+				errs[i] = fmt.Errorf("wait %s: %w", handle, err)
+				return
+			}
+			mu.Lock()
+			results[handle] = resp
+			mu.Unlock()
+		}(i, handle)
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
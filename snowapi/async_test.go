@@ -0,0 +1,116 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestExecuteAsync(t *testing.T) {
+	var cancelled bool
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/cancel"):
+			cancelled = true
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost:
+			if r.URL.Query().Get("async") != "true" {
+				t.Errorf("expected async=true, got %q", r.URL.RawQuery)
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1","statementStatusUrl":"/api/v2/statements/h1"}`))
+		default:
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"code":"333334"}`))
+				return
+			}
+			w.Write([]byte(`{"statementHandle":"h1","data":[["1"]]}`))
+		}
+	})
+
+	h, err := client.ExecuteAsync("SELECT 1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if h.Handle != "h1" || h.StatusURL != "/api/v2/statements/h1" {
+		t.Errorf("unexpected handle: %+v", h)
+	}
+
+	if _, status, err := h.Poll(); err != nil || status != http.StatusAccepted {
+		t.Errorf("expected 202 from first poll, got %d (err %v)", status, err)
+	}
+	resp, err := h.Wait(time.Millisecond, 3)
+	if err != nil || len(resp.Data) != 1 {
+		t.Errorf("unexpected wait result: %+v (err %v)", resp, err)
+	}
+	if err := h.Cancel(); err != nil || !cancelled {
+		t.Errorf("expected cancel to reach the server (err %v)", err)
+	}
+}
+
+func TestWaitForAll(t *testing.T) {
+	var mu sync.Mutex
+	polls := map[string]int{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		handle := path.Base(r.URL.Path)
+		mu.Lock()
+		polls[handle]++
+		n := polls[handle]
+		mu.Unlock()
+
+		switch {
+		case handle == "bad":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code":"002003","sqlState":"42S02","message":"Table 'T' does not exist","statementHandle":"bad"}`))
+		case handle == "slow" && n < 3:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"slow"}`))
+		default:
+			fmt.Fprintf(w, `{"code":"090001","statementHandle":%q,"data":[[%q]]}`, handle, handle)
+		}
+	})
+
+	strategy := PollStrategy{Initial: time.Millisecond, Max: time.Millisecond}
+	results, err := client.WaitForAll(context.Background(), []string{"fast", "slow", "bad", "fast"}, strategy)
+
+	if len(results) != 2 || results["fast"].Data[0][0] != "fast" || results["slow"].Data[0][0] != "slow" {
+		t.Errorf("unexpected results: %v", results)
+	}
+	if polls["slow"] != 3 || polls["fast"] != 1 {
+		t.Errorf("unexpected polls: %v", polls)
+	}
+	if !IsNotFound(err) || !strings.Contains(err.Error(), "wait bad:") || strings.Contains(err.Error(), "wait fast") {
+		t.Errorf("expected only the failing handle's error, got %v", err)
+	}
+}
+
+func TestWaitForAll_Canceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if path.Base(r.URL.Path) == "done" {
+			w.Write([]byte(`{"code":"090001","statementHandle":"done"}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":"333334"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	results, err := client.WaitForAll(ctx, []string{"done", "h1", "h2"}, PollStrategy{Initial: 5 * time.Millisecond, Max: 5 * time.Millisecond})
+
+	if len(results) != 1 || results["done"] == nil {
+		t.Errorf("expected the completed statement's result, got %v", results)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "wait h1:") || !strings.Contains(err.Error(), "wait h2:") {
+		t.Errorf("expected both pending handles to report the deadline, got %v", err)
+	}
+}
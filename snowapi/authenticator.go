@@ -0,0 +1,233 @@
+package snowapi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+// Authenticator supplies the bearer token sent with every request.
+type Authenticator interface {
+	// Token returns the bearer token for the next request.
+	Token() (string, error)
+	// TokenType is sent as X-Snowflake-Authorization-Token-Type.
+	TokenType() string
+}
+
+// tokenRefresher is implemented by authenticators that can mint a new token
+// on demand. When Snowflake answers 401 or 403, the client calls
+// RefreshToken and retries the request once with the new token.
+type tokenRefresher interface {
+	RefreshToken() (string, error)
+}
+
+// defaultRefreshSkew is how long before expiry a cached JWT is regenerated.
+const defaultRefreshSkew = 30 * time.Second
+
+// newSigner and signJWT are swapped out in tests to count key parsing and
+// signing operations.
+var (
+	newSigner = auth.NewSigner
+	signJWT   = (*auth.Signer).Token
+)
+
+// KeyPairAuthenticator signs a JWT with an RSA or ECDSA key pair. The signed
+// token is cached and reused until it is within RefreshSkew of expiring. The
+// key is parsed once, on first use; changing the key fields afterwards has
+// no effect, so rotate keys with SetPrivateKey.
+type KeyPairAuthenticator struct {
+	Account     string
+	User        string
+	PrivateKey  []byte        // PEM-encoded RSA or ECDSA private key (PKCS8)
+	PublicKey   []byte        // Optional: PEM-encoded public key; derived from PrivateKey when empty
+	Passphrase  []byte        // Optional: decrypts an encrypted PrivateKey
+	ExpireAfter time.Duration // defaults to 1h less IssuedAtSkew
+	RefreshSkew time.Duration // defaults to 30s
+
+	IssuedAtSkew time.Duration // backdates iat to tolerate clock drift
+	SetNotBefore bool          // also sets nbf to the backdated iat
+
+	Audience string                                         // overrides the "snowflake" aud claim
+	Issuer   func(account, user, fingerprint string) string // overrides the iss claim
+
+	mu        sync.Mutex
+	signer    *auth.Signer
+	token     string
+	expiresAt time.Time
+}
+
+// Token returns the cached JWT, signing a new one when it is near expiry.
+func (a *KeyPairAuthenticator) Token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	skew := a.RefreshSkew
+	if skew <= 0 {
+		skew = defaultRefreshSkew
+	}
+
+	now := time.Now()
+	if a.token != "" && now.Add(skew).Before(a.expiresAt) {
+		return a.token, nil
+	}
+	return a.sign(now)
+}
+
+// RefreshToken signs a new JWT regardless of the cached one, e.g. after
+// Snowflake rejected it because of clock drift.
+func (a *KeyPairAuthenticator) RefreshToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sign(time.Now())
+}
+
+// sign signs and caches a new token. Callers hold a.mu.
+func (a *KeyPairAuthenticator) sign(now time.Time) (string, error) {
+	if err := a.initSigner(); err != nil {
+		return "", err
+	}
+	token, err := signJWT(a.signer)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = token
+	a.expiresAt = now.Add(a.expireAfter())
+	return token, nil
+}
+
+// initSigner parses the key material on first use. Callers hold a.mu.
+func (a *KeyPairAuthenticator) initSigner() error {
+	if a.signer != nil {
+		return nil
+	}
+	signer, err := newSigner(a.tokenConfig(a.PrivateKey, a.PublicKey))
+	if err != nil {
+		return err
+	}
+	a.signer = signer
+	return nil
+}
+
+// SetPrivateKey rotates to a new key pair, keeping Passphrase for an
+// encrypted key; pub may be empty to derive it from priv. The cached JWT is
+// discarded, so the next request signs with the new key; requests already
+// sent keep the token they were given. On error the old key stays in use.
+func (a *KeyPairAuthenticator) SetPrivateKey(priv, pub []byte) error {
+	if err := auth.ValidateKeyPair(priv, pub, a.Passphrase); err != nil {
+		return fmt.Errorf("rotate key: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	signer, err := newSigner(a.tokenConfig(priv, pub))
+	if err != nil {
+		return fmt.Errorf("rotate key: %w", err)
+	}
+	a.PrivateKey, a.PublicKey = priv, pub
+	a.signer = signer
+	a.token = ""
+	a.expiresAt = time.Time{}
+	return nil
+}
+
+// expireAfter returns ExpireAfter, defaulting as NewClient does to the
+// longest lifetime Snowflake accepts once iat is backdated by IssuedAtSkew.
+func (a *KeyPairAuthenticator) expireAfter() time.Duration {
+	if a.ExpireAfter <= 0 {
+		return auth.MaxExpireAfter - a.IssuedAtSkew
+	}
+	return a.ExpireAfter
+}
+
+// tokenConfig returns the signer configuration for the given key pair.
+func (a *KeyPairAuthenticator) tokenConfig(priv, pub []byte) auth.TokenConfig {
+	return auth.TokenConfig{
+		Account:     a.Account,
+		User:        a.User,
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		Passphrase:  a.Passphrase,
+		ExpireAfter: a.expireAfter(),
+
+		IssuedAtSkew: a.IssuedAtSkew,
+		SetNotBefore: a.SetNotBefore,
+		Audience:     a.Audience,
+		Issuer:       a.Issuer,
+	}
+}
+
+// SetPrivateKey rotates the key pair of a client using key-pair
+// authentication; see KeyPairAuthenticator.SetPrivateKey. It is safe to call
+// while requests are in flight.
+func (c *Client) SetPrivateKey(priv, pub []byte) error {
+	kp, ok := c.auth.(*KeyPairAuthenticator)
+	if !ok {
+		return fmt.Errorf("SetPrivateKey requires key-pair authentication, client uses %T", c.auth)
+	}
+	return kp.SetPrivateKey(priv, pub)
+}
+
+// GenerateToken returns the bearer token the next request would send, for
+// checking key-pair setup with DecodeTokenClaims. The token is a live
+// credential until it expires: do not log it or share it.
+func (c *Client) GenerateToken() (string, error) {
+	return c.authToken()
+}
+
+// TokenClaims are the claims of a key-pair JWT. Issuer is
+// ACCOUNT.USER.SHA256:fingerprint by default, and Fingerprint is its
+// SHA256:... suffix, which should match RSA_PUBLIC_KEY_FP in DESCRIBE USER.
+type TokenClaims struct {
+	Issuer      string
+	Subject     string
+	Audience    []string
+	Fingerprint string
+	IssuedAt    time.Time
+	ExpiresAt   time.Time
+	NotBefore   time.Time // zero unless SetNotBefore
+}
+
+// DecodeTokenClaims parses the claims of a JWT such as GenerateToken returns.
+// It does not verify the signature, so use it only for troubleshooting.
+func DecodeTokenClaims(token string) (*TokenClaims, error) {
+	claims, err := auth.DecodeClaims(token)
+	if err != nil {
+		return nil, err
+	}
+	out := &TokenClaims{
+		Issuer:   claims.Issuer,
+		Subject:  claims.Subject,
+		Audience: claims.Audience,
+	}
+	if i := strings.LastIndex(claims.Issuer, "."); i >= 0 && strings.HasPrefix(claims.Issuer[i+1:], "SHA256:") {
+		out.Fingerprint = claims.Issuer[i+1:]
+	}
+	if claims.IssuedAt != nil {
+		out.IssuedAt = claims.IssuedAt.Time
+	}
+	if claims.ExpiresAt != nil {
+		out.ExpiresAt = claims.ExpiresAt.Time
+	}
+	if claims.NotBefore != nil {
+		out.NotBefore = claims.NotBefore.Time
+	}
+	return out, nil
+}
+
+// TokenType returns KEYPAIR_JWT.
+func (a *KeyPairAuthenticator) TokenType() string { return "KEYPAIR_JWT" }
+
+// OAuthAuthenticator uses an externally issued OAuth access token.
+type OAuthAuthenticator struct {
+	AccessToken string
+}
+
+// Token returns the configured access token.
+func (a *OAuthAuthenticator) Token() (string, error) { return a.AccessToken, nil }
+
+// TokenType returns OAUTH.
+func (a *OAuthAuthenticator) TokenType() string { return "OAUTH" }
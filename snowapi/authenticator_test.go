@@ -0,0 +1,340 @@
+package snowapi
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+func TestAuthenticator_TokenTypeHeader(t *testing.T) {
+	var gotAuth, gotType string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("X-Snowflake-Authorization-Token-Type")
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotType != "KEYPAIR_JWT" {
+		t.Errorf("expected KEYPAIR_JWT by default, got %q", gotType)
+	}
+
+	client.auth = &OAuthAuthenticator{AccessToken: "oauth-token"}
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer oauth-token" || gotType != "OAUTH" {
+		t.Errorf("unexpected OAuth headers: %q, %q", gotAuth, gotType)
+	}
+}
+
+func TestKeyPairAuthenticator_CachesToken(t *testing.T) {
+	var generated int32
+	orig := signJWT
+	signJWT = func(s *auth.Signer) (string, error) {
+		atomic.AddInt32(&generated, 1)
+		return orig(s)
+	}
+	defer func() { signJWT = orig }()
+
+	priv, pub := testKeyPair(t)
+	a := &KeyPairAuthenticator{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		ExpireAfter: time.Hour,
+	}
+
+	var wg sync.WaitGroup
+	tokens := make([]string, 20)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tok, err := a.Token()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tokens[i] = tok
+		}(i)
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&generated); n != 1 {
+		t.Errorf("expected 1 token to be generated, got %d", n)
+	}
+	for _, tok := range tokens {
+		if tok != tokens[0] {
+			t.Fatal("expected all callers to receive the cached token")
+		}
+	}
+}
+
+func TestKeyPairAuthenticator_RefreshesNearExpiry(t *testing.T) {
+	var generated int32
+	orig := signJWT
+	signJWT = func(s *auth.Signer) (string, error) {
+		atomic.AddInt32(&generated, 1)
+		return orig(s)
+	}
+	defer func() { signJWT = orig }()
+
+	priv, pub := testKeyPair(t)
+	a := &KeyPairAuthenticator{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		ExpireAfter: 10 * time.Second, // always within the default 30s skew
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := a.Token(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&generated); n != 3 {
+		t.Errorf("expected a new token per call near expiry, got %d", n)
+	}
+}
+
+func TestKeyPairAuthenticator_ParsesKeyOnce(t *testing.T) {
+	var parsed int32
+	orig := newSigner
+	newSigner = func(cfg auth.TokenConfig) (*auth.Signer, error) {
+		atomic.AddInt32(&parsed, 1)
+		return orig(cfg)
+	}
+	defer func() { newSigner = orig }()
+
+	priv, pub := testKeyPair(t)
+	a := &KeyPairAuthenticator{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		ExpireAfter: 10 * time.Second, // re-signs on every call
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := a.Token(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := atomic.LoadInt32(&parsed); n != 1 {
+		t.Errorf("expected the key to be parsed once, got %d", n)
+	}
+}
+
+func TestNewClient_JWTAudienceAndIssuer(t *testing.T) {
+	var got auth.TokenConfig
+	orig := newSigner
+	newSigner = func(cfg auth.TokenConfig) (*auth.Signer, error) {
+		got = cfg
+		return orig(cfg)
+	}
+	defer func() { newSigner = orig }()
+
+	priv, pub := testKeyPair(t)
+	_, err := NewClient(Config{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		JWTAudience: "custom-aud",
+		JWTIssuer:   func(account, user, fp string) string { return "" },
+	})
+	if err == nil || got.Audience != "custom-aud" || got.Issuer == nil {
+		t.Errorf("expected the overrides to reach the signer and the empty issuer to fail, got %v (cfg %+v)", err, got)
+	}
+}
+
+// issuerFingerprint returns the public key fingerprint in the iss claim of a
+// bearer token.
+func issuerFingerprint(t *testing.T, header string) string {
+	t.Helper()
+	parts := strings.Split(strings.TrimPrefix(header, "Bearer "), ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT, got %q", header)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("invalid JWT payload: %v", err)
+	}
+	var claims struct {
+		Iss string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("invalid JWT claims: %v", err)
+	}
+	return claims.Iss[strings.LastIndex(claims.Iss, ".")+1:]
+}
+
+// pemFingerprint computes Snowflake's SHA256 fingerprint of a PEM public key.
+func pemFingerprint(t *testing.T, pub []byte) string {
+	t.Helper()
+	block, _ := pem.Decode(pub)
+	sum := sha256.Sum256(block.Bytes)
+	return "SHA256:" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestClient_SetPrivateKey(t *testing.T) {
+	var gotAuth string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	oldPub := client.auth.(*KeyPairAuthenticator).PublicKey
+
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := issuerFingerprint(t, gotAuth); got != pemFingerprint(t, oldPub) {
+		t.Fatalf("unexpected initial fingerprint %s", got)
+	}
+
+	newPriv, newPub := testKeyPair(t)
+	if err := client.SetPrivateKey(newPriv, newPub); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := issuerFingerprint(t, gotAuth); got != pemFingerprint(t, newPub) {
+		t.Errorf("expected the rotated key's fingerprint, got %s", got)
+	}
+}
+
+func TestClient_SetPrivateKey_InvalidKeepsOldKey(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	kp := client.auth.(*KeyPairAuthenticator)
+	before, err := kp.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	otherPriv, _ := testKeyPair(t)
+	_, otherPub := testKeyPair(t)
+	for name, key := range map[string][2][]byte{
+		"unparsable": {[]byte("not a key"), nil},
+		"mismatched": {otherPriv, otherPub},
+	} {
+		if err := client.SetPrivateKey(key[0], key[1]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if after, err := kp.Token(); err != nil || after != before {
+		t.Errorf("expected the cached token to survive a failed rotation (err %v)", err)
+	}
+}
+
+func TestClient_SetPrivateKey_NotKeyPair(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	client.auth = &OAuthAuthenticator{AccessToken: "oauth-token"}
+	priv, pub := testKeyPair(t)
+	if err := client.SetPrivateKey(priv, pub); err == nil {
+		t.Error("expected an error for an OAuth client")
+	}
+}
+
+func TestKeyPairAuthenticator_SetPrivateKeyConcurrent(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	a := &KeyPairAuthenticator{Account: "TESTACCT", User: "TESTUSER", PrivateKey: priv, PublicKey: pub, ExpireAfter: time.Hour}
+	newPriv, newPub := testKeyPair(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := a.Token(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	if err := a.SetPrivateKey(newPriv, newPub); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	tok, err := a.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := issuerFingerprint(t, tok); got != pemFingerprint(t, newPub) {
+		t.Errorf("expected the rotated key's fingerprint, got %s", got)
+	}
+}
+
+func TestClient_GenerateToken(t *testing.T) {
+	var gotAuth string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	token, err := client.GenerateToken()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotAuth != "Bearer "+token {
+		t.Error("expected GenerateToken to return the token sent with requests")
+	}
+
+	claims, err := DecodeTokenClaims(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pub := client.auth.(*KeyPairAuthenticator).PublicKey
+	if claims.Subject != "TESTACCT.TESTUSER" || claims.Issuer != "TESTACCT.TESTUSER."+pemFingerprint(t, pub) {
+		t.Errorf("unexpected subject %q or issuer %q", claims.Subject, claims.Issuer)
+	}
+	if claims.Fingerprint != pemFingerprint(t, pub) {
+		t.Errorf("unexpected fingerprint %q", claims.Fingerprint)
+	}
+	if len(claims.Audience) != 1 || claims.Audience[0] != "snowflake" {
+		t.Errorf("unexpected audience %v", claims.Audience)
+	}
+	if !claims.ExpiresAt.After(claims.IssuedAt) || !claims.NotBefore.IsZero() {
+		t.Errorf("unexpected times iat=%v exp=%v nbf=%v", claims.IssuedAt, claims.ExpiresAt, claims.NotBefore)
+	}
+}
+
+func TestKeyPairAuthenticator_DefaultExpireAfter(t *testing.T) {
+	priv, _ := testKeyPair(t)
+	a := &KeyPairAuthenticator{Account: "A", User: "U", PrivateKey: priv, IssuedAtSkew: time.Minute}
+
+	token, err := a.Token()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := DecodeTokenClaims(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := claims.ExpiresAt.Sub(claims.IssuedAt); got != time.Hour {
+		t.Errorf("expected a zero ExpireAfter to default to the 1h maximum lifetime, got exp-iat=%v", got)
+	}
+	if again, err := a.Token(); err != nil || again != token {
+		t.Errorf("expected the defaulted token to be cached (err %v)", err)
+	}
+}
+
+func TestDecodeTokenClaims_Invalid(t *testing.T) {
+	if _, err := DecodeTokenClaims("oauth-token"); err == nil {
+		t.Error("expected an error for a non-JWT token")
+	}
+}
@@ -0,0 +1,151 @@
+package snowapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// DefaultBatchInsertSize is the number of rows BatchInsert sends per request
+// when Config.BatchInsertSize is unset.
+const DefaultBatchInsertSize = 1000
+
+// BatchInsert inserts rows into table with a single
+// INSERT INTO table (columns) VALUES (?, ...) statement per batch, binding
+// each column as an array across the batch's rows. Rows are split into
+// batches of Config.BatchInsertSize. It returns the total number of inserted
+// rows; on failure, the count covers the batches that completed.
+//
+// table and columns are inserted verbatim; use QuoteIdentifier for names
+// that need quoting. Each column's binding type is inferred from its first
+// non-nil value, and every other value in the column must map to the same
+// type (nil binds NULL). Every batch gets its own request ID so it can be
+// retried safely; opts.RequestID is ignored.
+func (c *Client) BatchInsert(table string, columns []string, rows [][]any, opts *RequestOptions) (int64, error) {
+	return c.BatchInsertContext(context.Background(), table, columns, rows, opts)
+}
+
+// BatchInsertContext is like BatchInsert but honors ctx.
+func (c *Client) BatchInsertContext(ctx context.Context, table string, columns []string, rows [][]any, opts *RequestOptions) (int64, error) {
+	if table == "" || len(columns) == 0 {
+		return 0, fmt.Errorf("batch insert requires a table and at least one column")
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	types, err := columnBindTypes(columns, rows)
+	if err != nil {
+		return 0, err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	statement := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders)
+
+	size := c.config.BatchInsertSize
+	if size <= 0 {
+		size = DefaultBatchInsertSize
+	}
+
+	var total int64
+	for start := 0; start < len(rows); start += size {
+		end := start + size
+		if end > len(rows) {
+			end = len(rows)
+		}
+		n, err := c.insertBatch(ctx, statement, columns, types, rows[start:end], start, opts)
+		total += n
+		if err != nil {
+			return total, fmt.Errorf("batch insert rows %d-%d: %w", start, end-1, err)
+		}
+	}
+	return total, nil
+}
+
+// insertBatch submits one batch and waits for it to complete. offset is the
+// index of batch[0] in the caller's rows, for error messages.
+func (c *Client) insertBatch(ctx context.Context, statement string, columns, types []string, batch [][]any, offset int, opts *RequestOptions) (int64, error) {
+	bindings := make(map[string]Binding, len(columns))
+	for col := range columns {
+		values := make([]*string, len(batch))
+		for i, row := range batch {
+			if row[col] == nil {
+				continue
+			}
+			b, err := toBinding(row[col])
+			if err != nil {
+				return 0, fmt.Errorf("row %d column %s: %w", offset+i, columns[col], err)
+			}
+			if b.Type != types[col] {
+				return 0, fmt.Errorf("row %d column %s: %s value in %s column", offset+i, columns[col], b.Type, types[col])
+			}
+			values[i] = b.Value
+		}
+		bindings[strconv.Itoa(col+1)] = Binding{Type: types[col], Values: values}
+	}
+
+	batchOpts := RequestOptions{}
+	if opts != nil {
+		batchOpts = *opts
+	}
+	batchOpts.RequestID = uuid.New().String()
+
+	body, err := c.newQueryRequest(statement, &batchOpts)
+	if err != nil {
+		return 0, err
+	}
+	body.Bindings = bindings
+	resp, err := c.submit(ctx, body, false, &batchOpts)
+	if err != nil {
+		return 0, err
+	}
+	resp, err = c.awaitCompletion(ctx, resp, body)
+	if err != nil {
+		return 0, err
+	}
+	return resp.RowsAffected()
+}
+
+// columnBindTypes checks every row's width and infers each column's binding
+// type from its first non-nil value; all-NULL columns bind as TEXT.
+func columnBindTypes(columns []string, rows [][]any) ([]string, error) {
+	types := make([]string, len(columns))
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(columns))
+		}
+		for col, v := range row {
+			if types[col] != "" || v == nil {
+				continue
+			}
+			b, err := toBinding(v)
+			if err != nil {
+				return nil, fmt.Errorf("row %d column %s: %w", i, columns[col], err)
+			}
+			types[col] = b.Type
+		}
+	}
+	for col := range types {
+		if types[col] == "" {
+			types[col] = "TEXT"
+		}
+	}
+	return types, nil
+}
+
+// MarshalJSON encodes Values, when set, as the binding's value array.
+func (b Binding) MarshalJSON() ([]byte, error) {
+	if b.Values == nil {
+		return json.Marshal(struct {
+			Type  string  `json:"type"`
+			Value *string `json:"value"`
+		}{b.Type, b.Value})
+	}
+	return json.Marshal(struct {
+		Type  string    `json:"type"`
+		Value []*string `json:"value"`
+	}{b.Type, b.Values})
+}
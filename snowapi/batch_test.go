@@ -0,0 +1,96 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBatchInsert(t *testing.T) {
+	type batchBody struct {
+		Statement string `json:"statement"`
+		Bindings  map[string]struct {
+			Type  string    `json:"type"`
+			Value []*string `json:"value"`
+		} `json:"bindings"`
+	}
+	var bodies []batchBody
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body batchBody
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		bodies = append(bodies, body)
+		n := len(body.Bindings["1"].Value)
+		fmt.Fprintf(w, `{"code":"090001","resultSetMetaData":{"rowType":[{"name":"number of rows inserted","type":"fixed"}]},"data":[["%d"]]}`, n)
+	})
+	client.config.BatchInsertSize = 2
+
+	rows := [][]any{
+		{1, nil},
+		{2, "b"},
+		{3, "c"},
+	}
+	n, err := client.BatchInsert("USERS", []string{"ID", "NAME"}, rows, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("expected 3 rows inserted, got %d", n)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 batches, got %d", len(bodies))
+	}
+	if bodies[0].Statement != "INSERT INTO USERS (ID, NAME) VALUES (?, ?)" {
+		t.Errorf("unexpected statement: %q", bodies[0].Statement)
+	}
+
+	ids, names := bodies[0].Bindings["1"], bodies[0].Bindings["2"]
+	if ids.Type != "FIXED" || names.Type != "TEXT" {
+		t.Errorf("unexpected inferred types: %s, %s", ids.Type, names.Type)
+	}
+	if len(ids.Value) != 2 || *ids.Value[1] != "2" || names.Value[0] != nil || *names.Value[1] != "b" {
+		t.Errorf("unexpected first batch bindings: %+v", bodies[0].Bindings)
+	}
+	if v := bodies[1].Bindings["1"].Value; len(v) != 1 || *v[0] != "3" {
+		t.Errorf("unexpected second batch bindings: %+v", bodies[1].Bindings)
+	}
+}
+
+func TestBatchInsert_Errors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	})
+
+	tests := []struct {
+		name string
+		rows [][]any
+		want string
+	}{
+		{"ragged row", [][]any{{1, "a"}, {2}}, "row 1 has 1 values, expected 2"},
+		{"mixed types", [][]any{{1, "a"}, {"two", "b"}}, "row 1 column ID: TEXT value in FIXED column"},
+		{"unsupported", [][]any{{1, struct{}{}}}, "unsupported type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.BatchInsert("USERS", []string{"ID", "NAME"}, tt.rows, nil)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestBinding_MarshalJSON(t *testing.T) {
+	v := "1"
+	single, _ := json.Marshal(Binding{Type: "FIXED", Value: &v})
+	array, _ := json.Marshal(Binding{Type: "FIXED", Values: []*string{&v, nil}})
+	if string(single) != `{"type":"FIXED","value":"1"}` {
+		t.Errorf("unexpected single binding: %s", single)
+	}
+	if string(array) != `{"type":"FIXED","value":["1",null]}` {
+		t.Errorf("unexpected array binding: %s", array)
+	}
+}
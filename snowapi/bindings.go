@@ -0,0 +1,206 @@
+package snowapi
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampBindLayout matches Snowflake's default TIMESTAMP input format.
+const timestampBindLayout = "2006-01-02 15:04:05.000000000"
+
+// timestampTZBindLayout is timestampBindLayout with the UTC offset, as
+// TIMESTAMP_LTZ and TIMESTAMP_TZ bindings carry it.
+const timestampTZBindLayout = timestampBindLayout + " -07:00"
+
+// TimestampKind selects the Snowflake timestamp type a time.Time binds as.
+type TimestampKind int
+
+const (
+	// TimestampNTZ binds the wall clock time, dropping the location. Plain
+	// time.Time bindings use it.
+	TimestampNTZ TimestampKind = iota
+	// TimestampLTZ binds the instant; Snowflake shows it in the session
+	// time zone.
+	TimestampLTZ
+	// TimestampTZ binds the wall clock time with its UTC offset.
+	TimestampTZ
+)
+
+// String returns the Snowflake type name, e.g. "TIMESTAMP_TZ".
+func (k TimestampKind) String() string {
+	switch k {
+	case TimestampNTZ:
+		return "TIMESTAMP_NTZ"
+	case TimestampLTZ:
+		return "TIMESTAMP_LTZ"
+	case TimestampTZ:
+		return "TIMESTAMP_TZ"
+	}
+	return fmt.Sprintf("TimestampKind(%d)", int(k))
+}
+
+// Timestamp is a time.Time bound as a specific timestamp type; see
+// BindTimestamp.
+type Timestamp struct {
+	Time time.Time
+	Kind TimestampKind
+}
+
+// BindTimestamp wraps t for ExecuteWithBindings so it binds as kind instead
+// of the TIMESTAMP_NTZ used for a plain time.Time.
+func BindTimestamp(t time.Time, kind TimestampKind) Timestamp {
+	return Timestamp{Time: t, Kind: kind}
+}
+
+// ExecuteWithBindings submits a statement with positional (?) bind variables.
+func (c *Client) ExecuteWithBindings(statement string, bindings []any, opts *RequestOptions) (*QueryResponse, error) {
+	return c.ExecuteWithBindingsContext(context.Background(), statement, bindings, opts)
+}
+
+// ExecuteWithBindingsContext is like ExecuteWithBindings but honors ctx.
+func (c *Client) ExecuteWithBindingsContext(ctx context.Context, statement string, bindings []any, opts *RequestOptions) (*QueryResponse, error) {
+	bound, err := buildBindings(bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+	body.Bindings = bound
+	return c.submit(ctx, body, false, opts)
+}
+
+// ExecuteWithNamedBindings submits a statement with named (:name) bind
+// variables. Keys of bindings are placeholder names without the colon; every
+// placeholder must have a value and every value must be used.
+func (c *Client) ExecuteWithNamedBindings(statement string, bindings map[string]any, opts *RequestOptions) (*QueryResponse, error) {
+	return c.ExecuteWithNamedBindingsContext(context.Background(), statement, bindings, opts)
+}
+
+// ExecuteWithNamedBindingsContext is like ExecuteWithNamedBindings but honors
+// ctx.
+func (c *Client) ExecuteWithNamedBindingsContext(ctx context.Context, statement string, bindings map[string]any, opts *RequestOptions) (*QueryResponse, error) {
+	rewritten, values, err := bindNamed(statement, bindings)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExecuteWithBindingsContext(ctx, rewritten, values, opts)
+}
+
+// bindNamed rewrites each :name placeholder in statement to a positional ?
+// and returns the values in placeholder order. A name used more than once is
+// bound once per occurrence, since the SQL API only supports positional
+// bindings.
+func bindNamed(statement string, bindings map[string]any) (string, []any, error) {
+	var (
+		values []any
+		used   = make(map[string]bool)
+		unused []string
+		extra  []string
+	)
+	rewritten := replacePlaceholders(statement, func(name string) string {
+		v, ok := bindings[name]
+		if !ok {
+			if !used[name] {
+				unused = append(unused, ":"+name)
+			}
+			used[name] = true
+			return ":" + name
+		}
+		used[name] = true
+		values = append(values, v)
+		return "?"
+	})
+	for name := range bindings {
+		if !used[name] {
+			extra = append(extra, name)
+		}
+	}
+	if len(unused) == 0 && len(extra) == 0 {
+		return rewritten, values, nil
+	}
+
+	var problems []string
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		problems = append(problems, "unbound parameters "+strings.Join(unused, ", "))
+	}
+	if len(extra) > 0 {
+		sort.Strings(extra)
+		problems = append(problems, "extra parameters "+strings.Join(extra, ", "))
+	}
+	return "", nil, fmt.Errorf("named bindings: %s", strings.Join(problems, "; "))
+}
+
+// buildBindings converts Go values into Snowflake binding descriptors.
+func buildBindings(values []any) (map[string]Binding, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	bindings := make(map[string]Binding, len(values))
+	for i, v := range values {
+		b, err := toBinding(v)
+		if err != nil {
+			return nil, fmt.Errorf("binding %d: %w", i+1, err)
+		}
+		bindings[strconv.Itoa(i+1)] = b
+	}
+	return bindings, nil
+}
+
+// toBinding maps a single Go value to its Snowflake type and string value.
+func toBinding(v any) (Binding, error) {
+	var typ, val string
+
+	switch x := v.(type) {
+	case nil:
+		return Binding{Type: "TEXT"}, nil
+	case string:
+		typ, val = "TEXT", x
+	case int:
+		typ, val = "FIXED", strconv.FormatInt(int64(x), 10)
+	case int32:
+		typ, val = "FIXED", strconv.FormatInt(int64(x), 10)
+	case int64:
+		typ, val = "FIXED", strconv.FormatInt(x, 10)
+	case uint32:
+		typ, val = "FIXED", strconv.FormatUint(uint64(x), 10)
+	case uint64:
+		typ, val = "FIXED", strconv.FormatUint(x, 10)
+	case float32:
+		typ, val = "REAL", strconv.FormatFloat(float64(x), 'g', -1, 32)
+	case float64:
+		typ, val = "REAL", strconv.FormatFloat(x, 'g', -1, 64)
+	case bool:
+		typ, val = "BOOLEAN", strconv.FormatBool(x)
+	case time.Time:
+		typ, val = "TIMESTAMP_NTZ", x.Format(timestampBindLayout)
+	case Timestamp:
+		switch x.Kind {
+		case TimestampNTZ:
+			val = x.Time.Format(timestampBindLayout)
+		case TimestampLTZ, TimestampTZ:
+			val = x.Time.Format(timestampTZBindLayout)
+		default:
+			return Binding{}, fmt.Errorf("unsupported timestamp kind %v", x.Kind)
+		}
+		typ = x.Kind.String()
+	case []byte:
+		if x == nil {
+			return Binding{Type: "BINARY"}, nil
+		}
+		typ, val = "BINARY", hex.EncodeToString(x)
+	default:
+		return Binding{}, fmt.Errorf("unsupported type %T", v)
+	}
+
+	return Binding{Type: typ, Value: &val}, nil
+}
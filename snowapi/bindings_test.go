@@ -0,0 +1,199 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestBuildBindings(t *testing.T) {
+	ts := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)
+
+	bindings, err := buildBindings([]any{"abc", int64(42), 1.5, true, ts, []byte{0xde, 0xad}, nil})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key, typ string
+		value    *string
+	}{
+		{"1", "TEXT", strPtr("abc")},
+		{"2", "FIXED", strPtr("42")},
+		{"3", "REAL", strPtr("1.5")},
+		{"4", "BOOLEAN", strPtr("true")},
+		{"5", "TIMESTAMP_NTZ", strPtr("2024-03-15 10:30:45.123000000")},
+		{"6", "BINARY", strPtr("dead")},
+		{"7", "TEXT", nil},
+	}
+	for _, tt := range tests {
+		got, ok := bindings[tt.key]
+		if !ok {
+			t.Fatalf("missing binding %s", tt.key)
+		}
+		if got.Type != tt.typ {
+			t.Errorf("binding %s: expected type %s, got %s", tt.key, tt.typ, got.Type)
+		}
+		if (got.Value == nil) != (tt.value == nil) || (got.Value != nil && *got.Value != *tt.value) {
+			t.Errorf("binding %s: unexpected value %v", tt.key, got.Value)
+		}
+	}
+}
+
+func TestBuildBindings_UnsupportedType(t *testing.T) {
+	_, err := buildBindings([]any{"ok", struct{}{}})
+	if err == nil || err.Error() != "binding 2: unsupported type struct {}" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExecuteWithBindings_RequestBody(t *testing.T) {
+	var got QueryRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"code":"090001","data":[]}`))
+	})
+
+	_, err := client.ExecuteWithBindings("SELECT * FROM t WHERE id = ? AND name = ?", []any{int64(7), nil}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Bindings["1"].Type != "FIXED" || *got.Bindings["1"].Value != "7" {
+		t.Errorf("unexpected binding 1: %+v", got.Bindings["1"])
+	}
+	if got.Bindings["2"].Value != nil {
+		t.Errorf("expected NULL binding 2, got %v", *got.Bindings["2"].Value)
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestBindNamed(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	stmt, values, err := bindNamed(
+		"SELECT ':skip', $$:skip$$, x::date FROM t WHERE a = :id AND b = :at AND c = :note AND d = :id",
+		map[string]any{"id": int64(7), "at": ts, "note": nil},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "SELECT ':skip', $$:skip$$, x::date FROM t WHERE a = ? AND b = ? AND c = ? AND d = ?"
+	if stmt != want {
+		t.Errorf("statement = %q, want %q", stmt, want)
+	}
+	if len(values) != 4 || values[0] != int64(7) || values[1] != ts || values[2] != nil || values[3] != int64(7) {
+		t.Errorf("unexpected values: %v", values)
+	}
+}
+
+func TestBindNamed_VariantPathsAndComments(t *testing.T) {
+	stmt, values, err := bindNamed("SELECT v:id, src:\"a\".b FROM t -- :ignored\nWHERE k = :k /* :other */", map[string]any{"k": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "SELECT v:id, src:\"a\".b FROM t -- :ignored\nWHERE k = ? /* :other */"; stmt != want {
+		t.Errorf("statement = %q, want %q", stmt, want)
+	}
+	if len(values) != 1 || values[0] != 1 {
+		t.Errorf("unexpected values: %v", values)
+	}
+}
+
+func TestBindNamed_Mismatch(t *testing.T) {
+	_, _, err := bindNamed("SELECT :b, :a, :b, :known", map[string]any{"known": 1, "z": 2, "y": 3})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	want := "named bindings: unbound parameters :a, :b; extra parameters y, z"
+	if err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+
+	_, _, err = bindNamed("SELECT 1", map[string]any{"id": 1})
+	if err == nil || err.Error() != "named bindings: extra parameters id" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestExecuteWithNamedBindings_RequestBody(t *testing.T) {
+	var got QueryRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{"code":"090001","data":[]}`))
+	})
+
+	ts := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	_, err := client.ExecuteWithNamedBindings(
+		"UPDATE t SET deleted = :deleted, updated_at = :now WHERE id = :id",
+		map[string]any{"id": 42, "deleted": nil, "now": ts},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Statement != "UPDATE t SET deleted = ?, updated_at = ? WHERE id = ?" {
+		t.Errorf("unexpected statement: %q", got.Statement)
+	}
+	if b := got.Bindings["1"]; b.Type != "TEXT" || b.Value != nil {
+		t.Errorf("expected NULL binding 1, got %+v", b)
+	}
+	if b := got.Bindings["2"]; b.Type != "TIMESTAMP_NTZ" || *b.Value != "2024-03-01 12:30:00.000000000" {
+		t.Errorf("unexpected binding 2: %+v", b)
+	}
+	if b := got.Bindings["3"]; b.Type != "FIXED" || *b.Value != "42" {
+		t.Errorf("unexpected binding 3: %+v", b)
+	}
+}
+
+func TestExecuteWithNamedBindings_NoRequestOnMismatch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	if _, err := client.ExecuteWithNamedBindings("SELECT :a", nil, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestToBinding_Timestamp(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+30*60)
+	ts := time.Date(2024, 3, 15, 10, 30, 45, 123000000, ist)
+
+	tests := []struct {
+		value any
+		typ   string
+		want  string
+	}{
+		{ts, "TIMESTAMP_NTZ", "2024-03-15 10:30:45.123000000"},
+		{BindTimestamp(ts, TimestampNTZ), "TIMESTAMP_NTZ", "2024-03-15 10:30:45.123000000"},
+		{BindTimestamp(ts, TimestampLTZ), "TIMESTAMP_LTZ", "2024-03-15 10:30:45.123000000 +05:30"},
+		{BindTimestamp(ts, TimestampTZ), "TIMESTAMP_TZ", "2024-03-15 10:30:45.123000000 +05:30"},
+		{BindTimestamp(ts.UTC(), TimestampTZ), "TIMESTAMP_TZ", "2024-03-15 05:00:45.123000000 +00:00"},
+	}
+	for _, tt := range tests {
+		got, err := toBinding(tt.value)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tt.value, err)
+		}
+		if got.Type != tt.typ || got.Value == nil || *got.Value != tt.want {
+			t.Errorf("%v: got %s %v, want %s %q", tt.value, got.Type, got.Value, tt.typ, tt.want)
+		}
+	}
+
+	if _, err := toBinding(BindTimestamp(ts, TimestampKind(7))); err == nil {
+		t.Error("expected an error for an unknown timestamp kind")
+	}
+}
+
+func TestTimestampKind_String(t *testing.T) {
+	for kind, want := range map[TimestampKind]string{
+		TimestampNTZ:     "TIMESTAMP_NTZ",
+		TimestampLTZ:     "TIMESTAMP_LTZ",
+		TimestampTZ:      "TIMESTAMP_TZ",
+		TimestampKind(9): "TimestampKind(9)",
+	} {
+		if got := kind.String(); got != want {
+			t.Errorf("%d: got %q, want %q", int(kind), got, want)
+		}
+	}
+}
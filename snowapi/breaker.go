@@ -0,0 +1,142 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting Snowflake while the circuit
+// breaker configured by Config.CircuitBreakerThreshold is open.
+var ErrCircuitOpen = errors.New("snowapi: circuit breaker is open")
+
+// defaultCircuitBreakerCooldown is how long the circuit stays open when
+// Config.CircuitBreakerCooldown is unset.
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// CircuitState is the state of the client's circuit breaker.
+type CircuitState int
+
+const (
+	CircuitClosed   CircuitState = iota // requests flow normally
+	CircuitOpen                         // requests fail fast with ErrCircuitOpen
+	CircuitHalfOpen                     // one probe request is testing recovery
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+	return "closed"
+}
+
+// breaker trips open after threshold consecutive failed requests and lets a
+// single probe through once cooldown has passed. A nil breaker allows every
+// request.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	if threshold <= 0 {
+		return nil
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCircuitBreakerCooldown
+	}
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent, moving an open circuit whose
+// cooldown has passed to half-open with the caller as the probe.
+func (b *breaker) allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = CircuitHalfOpen
+	case CircuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+	default:
+		return nil
+	}
+	b.probing = true
+	return nil
+}
+
+// done records the outcome of a request allowed by allow: transient network
+// errors and 429 or 5xx responses count as failures, any other response as
+// a success, and anything else (a done context, a local error, an unknown
+// host or certificate error) as neither, since retrying later won't fix it.
+func (b *breaker) done(ctx context.Context, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+	switch {
+	case ctx.Err() != nil:
+		b.release()
+	case err == nil && resp != nil:
+		if isRetryableStatus(resp.StatusCode) {
+			b.failure()
+		} else {
+			b.success()
+		}
+	case isTransientNetError(err):
+		b.failure()
+	default:
+		b.release()
+	}
+}
+
+func (b *breaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state, b.failures, b.probing = CircuitClosed, 0, false
+}
+
+func (b *breaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.threshold {
+		b.state, b.openedAt = CircuitOpen, b.now()
+	}
+	b.probing = false
+}
+
+// release frees the probe slot after an inconclusive request.
+func (b *breaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *breaker) currentState() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,145 @@
+package snowapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		calls   atomic.Int32
+		healthy atomic.Bool
+	)
+	priv, pub := testKeyPair(t)
+	client, err := NewClient(Config{
+		Account:                 "TESTACCT",
+		User:                    "TESTUSER",
+		PrivateKey:              priv,
+		PublicKey:               pub,
+		CircuitBreakerThreshold: 3,
+		CircuitBreakerCooldown:  time.Minute,
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			calls.Add(1)
+			if !healthy.Load() {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"code":"090001","data":[["1"]]}`)),
+			}, nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	now := time.Now()
+	client.breaker.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Poll("h1", 0); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("poll %d: expected a transport error, got %v", i, err)
+		}
+	}
+	if got := client.Stats().Circuit; got != CircuitOpen {
+		t.Fatalf("expected the circuit to open after 3 failures, got %v", got)
+	}
+
+	// Open: calls fail fast without reaching the transport.
+	before := calls.Load()
+	if _, err := client.Query("SELECT 1"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen from Query, got %v", err)
+	}
+	if _, _, err := client.Poll("h1", 0); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen from Poll, got %v", err)
+	}
+	if calls.Load() != before {
+		t.Error("expected no requests while the circuit is open")
+	}
+
+	// Half-open: a failed probe reopens the circuit.
+	now = now.Add(time.Minute)
+	if _, _, err := client.Poll("h1", 0); err == nil || errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the probe to reach the transport, got %v", err)
+	}
+	if got := client.Stats().Circuit; got != CircuitOpen {
+		t.Fatalf("expected a failed probe to reopen the circuit, got %v", got)
+	}
+	if _, _, err := client.Poll("h1", 0); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected ErrCircuitOpen after the failed probe, got %v", err)
+	}
+
+	// A successful probe closes it.
+	now = now.Add(time.Minute)
+	healthy.Store(true)
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error from the probe: %v", err)
+	}
+	if got := client.Stats().Circuit; got != CircuitClosed {
+		t.Errorf("expected a successful probe to close the circuit, got %v", got)
+	}
+}
+
+func TestBreaker_HalfOpenAllowsOneProbe(t *testing.T) {
+	b := newBreaker(1, time.Second)
+	now := time.Now()
+	b.now = func() time.Time { return now }
+
+	b.failure()
+	now = now.Add(time.Second)
+	if err := b.allow(); err != nil {
+		t.Fatalf("expected the first caller to probe, got %v", err)
+	}
+	if err := b.allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("expected other callers to wait for the probe, got %v", err)
+	}
+	b.release() // inconclusive probe, e.g. its context was canceled
+	if err := b.allow(); err != nil {
+		t.Errorf("expected a new probe after release, got %v", err)
+	}
+	if b.currentState() != CircuitHalfOpen {
+		t.Errorf("expected half-open, got %v", b.currentState())
+	}
+}
+
+func TestBreaker_Disabled(t *testing.T) {
+	b := newBreaker(0, 0)
+	if b != nil || b.allow() != nil || b.currentState() != CircuitClosed {
+		t.Error("expected a zero threshold to disable the breaker")
+	}
+}
+
+func TestBreaker_IgnoresConfigurationErrors(t *testing.T) {
+	b := newBreaker(1, time.Minute)
+	for _, err := range []error{
+		&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "h", IsNotFound: true}},
+		&HostError{Host: "h", Err: errors.New("no such host")},
+		&tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}},
+	} {
+		if err := b.allow(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		b.done(context.Background(), nil, err)
+	}
+	if b.currentState() != CircuitClosed {
+		t.Errorf("expected DNS and TLS errors not to open the circuit, got %v", b.currentState())
+	}
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b.done(context.Background(), nil, &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET})
+	if b.currentState() != CircuitOpen {
+		t.Errorf("expected a reset connection to count as a failure, got %v", b.currentState())
+	}
+}
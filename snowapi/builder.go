@@ -0,0 +1,271 @@
+package snowapi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// QueryBuilder fills :name placeholders in a statement template with safely
+// quoted identifiers and literals:
+//
+//	sql, err := snowapi.NewQuery("SELECT * FROM :tbl WHERE id = :id").
+//		Ident("tbl", name).
+//		Arg("id", 5).
+//		Build()
+//
+// Prefer bindings (ExecuteWithBindings) for values; the builder is for
+// identifiers, which cannot be bound, and for code migrating off string
+// concatenation. Placeholders inside quoted strings, quoted identifiers and
+// $$-delimited strings are left alone, as are :: casts.
+type QueryBuilder struct {
+	template string
+	values   map[string]string
+	err      error
+}
+
+// NewQuery starts a builder for template.
+func NewQuery(template string) *QueryBuilder {
+	return &QueryBuilder{template: template, values: map[string]string{}}
+}
+
+// Ident sets placeholder name to a double-quoted identifier. Dotted names
+// (db.schema.table) quote each part. Quoted identifiers are case-sensitive,
+// so pass upper-case names to match unquoted ones.
+func (b *QueryBuilder) Ident(name, ident string) *QueryBuilder {
+	quoted, err := QuoteIdentifier(ident)
+	if err != nil {
+		b.setErr(fmt.Errorf("placeholder :%s: %w", name, err))
+		return b
+	}
+	b.values[name] = quoted
+	return b
+}
+
+// Arg sets placeholder name to a SQL literal for value; see QuoteLiteral.
+func (b *QueryBuilder) Arg(name string, value any) *QueryBuilder {
+	lit, err := QuoteLiteral(value)
+	if err != nil {
+		b.setErr(fmt.Errorf("placeholder :%s: %w", name, err))
+		return b
+	}
+	b.values[name] = lit
+	return b
+}
+
+// Build returns the statement with every placeholder replaced, or an error
+// for an invalid value or a placeholder without one.
+func (b *QueryBuilder) Build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	var missing string
+	out := replacePlaceholders(b.template, func(name string) string {
+		v, ok := b.values[name]
+		if !ok && missing == "" {
+			missing = name
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("no value for placeholder :%s", missing)
+	}
+	return out, nil
+}
+
+// MustBuild is like Build but panics on error.
+func (b *QueryBuilder) MustBuild() string {
+	s, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (b *QueryBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// QuoteIdentifier double-quotes each dot-separated part of ident, doubling
+// embedded double quotes. Empty parts, control characters and parts longer
+// than 255 characters are rejected.
+func QuoteIdentifier(ident string) (string, error) {
+	parts := strings.Split(ident, ".")
+	for i, part := range parts {
+		if part == "" || len(part) > 255 {
+			return "", fmt.Errorf("invalid identifier %q", ident)
+		}
+		for _, r := range part {
+			if unicode.IsControl(r) {
+				return "", fmt.Errorf("invalid identifier %q: control character", ident)
+			}
+		}
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `""`) + `"`
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// QuoteLiteral renders value as a Snowflake SQL literal. Strings are single
+// quoted with quotes and backslashes escaped; nil is NULL; integers, floats,
+// bools, Decimal, time.Time (TIMESTAMP_TZ) and []byte (hex BINARY) are
+// supported.
+func QuoteLiteral(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "NULL", nil
+	case string:
+		return quoteString(v), nil
+	case bool:
+		if v {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case int:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float32:
+		return formatFloatLiteral(float64(v))
+	case float64:
+		return formatFloatLiteral(v)
+	case Decimal:
+		return v.String(), nil
+	case time.Time:
+		return quoteString(v.Format("2006-01-02 15:04:05.999999999 -07:00")) + "::TIMESTAMP_TZ", nil
+	case []byte:
+		return "X'" + hex.EncodeToString(v) + "'", nil
+	}
+	return "", fmt.Errorf("unsupported literal type %T", value)
+}
+
+func quoteString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func formatFloatLiteral(f float64) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("unsupported float literal %v", f)
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64), nil
+}
+
+// replacePlaceholders returns src with each :name placeholder replaced by
+// replace(name), leaving quoted strings, quoted identifiers, $$-delimited
+// strings, comments and :: casts alone. A colon directly after an
+// identifier, ], ) or " is a VARIANT path (src:name, arr[0]:id), not a
+// placeholder.
+func replacePlaceholders(src string, replace func(name string) string) string {
+	var out strings.Builder
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := closingQuote(src, i)
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "$$"):
+			end := strings.Index(src[i+2:], "$$")
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i + 4
+			}
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "--") || strings.HasPrefix(src[i:], "//"):
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i
+			}
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				end = len(src)
+			} else {
+				end += i + 4
+			}
+			out.WriteString(src[i:end])
+			i = end
+		case strings.HasPrefix(src[i:], "::"):
+			out.WriteString("::")
+			i += 2
+		case c == ':' && i+1 < len(src) && isPlaceholderStart(src[i+1]) && !isPathPrefix(src, i):
+			j := i + 1
+			for j < len(src) && isPlaceholderChar(src[j]) {
+				j++
+			}
+			out.WriteString(replace(src[i+1 : j]))
+			i = j
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+	return out.String()
+}
+
+// closingQuote returns the index just past the quoted section starting at
+// src[start], treating a doubled quote (and, in strings, a backslash) as an
+// escape.
+func closingQuote(src string, start int) int {
+	q := src[start]
+	for i := start + 1; i < len(src); i++ {
+		switch {
+		case q == '\'' && src[i] == '\\':
+			i++
+		case src[i] == q:
+			if i+1 < len(src) && src[i+1] == q {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(src)
+}
+
+// isPathPrefix reports whether the colon at src[i] follows an expression, as
+// in a VARIANT path, rather than starting a placeholder.
+func isPathPrefix(src string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	c := src[i-1]
+	return isPlaceholderChar(c) || c == '$' || c == ']' || c == ')' || c == '"'
+}
+
+func isPlaceholderStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isPlaceholderChar(c byte) bool {
+	return isPlaceholderStart(c) || c >= '0' && c <= '9'
+}
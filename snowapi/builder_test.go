@@ -0,0 +1,144 @@
+package snowapi
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	got, err := NewQuery("SELECT * FROM :tbl WHERE id = :id AND name = :name").
+		Ident("tbl", "MYDB.PUBLIC.USERS").
+		Arg("id", 5).
+		Arg("name", "O'Brien").
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `SELECT * FROM "MYDB"."PUBLIC"."USERS" WHERE id = 5 AND name = 'O''Brien'`
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQueryBuilder_Injection(t *testing.T) {
+	tests := []struct {
+		name  string
+		build *QueryBuilder
+		want  string
+	}{
+		{
+			"literal quote",
+			NewQuery("SELECT * FROM t WHERE name = :v").Arg("v", "x'; DROP TABLE t; --"),
+			`SELECT * FROM t WHERE name = 'x''; DROP TABLE t; --'`,
+		},
+		{
+			"literal backslash escape",
+			NewQuery("SELECT :v").Arg("v", `\'; DROP TABLE t; --`),
+			`SELECT '\\''; DROP TABLE t; --'`,
+		},
+		{
+			"identifier quote",
+			NewQuery("SELECT * FROM :tbl").Ident("tbl", `T"; DROP TABLE T; --`),
+			`SELECT * FROM "T""; DROP TABLE T; --"`,
+		},
+		{
+			"identifier semicolon",
+			NewQuery("SELECT * FROM :tbl").Ident("tbl", "USERS;DELETE FROM USERS"),
+			`SELECT * FROM "USERS;DELETE FROM USERS"`,
+		},
+		{
+			"value containing placeholder",
+			NewQuery("SELECT :a, :b").Arg("a", ":b").Arg("b", 1),
+			`SELECT ':b', 1`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.build.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilder_SkipsQuotedAndCasts(t *testing.T) {
+	got, err := NewQuery(`SELECT ':id', ":id", $$ :id $$, v::int, :id FROM t`).Arg("id", 7).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `SELECT ':id', ":id", $$ :id $$, v::int, 7 FROM t`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQueryBuilder_SkipsVariantPaths(t *testing.T) {
+	got, err := NewQuery(`SELECT src:name, v:"a".b, arr[0]:id, (v):x, "col":y, $1:z FROM t WHERE k = :k AND j=:k`).Arg("k", 7).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `SELECT src:name, v:"a".b, arr[0]:id, (v):x, "col":y, $1:z FROM t WHERE k = 7 AND j=7`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQueryBuilder_SkipsComments(t *testing.T) {
+	got, err := NewQuery("SELECT :id -- filter on :name\nFROM t /* :tbl, 'x */ // and :other\nWHERE a = :id").Arg("id", 7).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "SELECT 7 -- filter on :name\nFROM t /* :tbl, 'x */ // and :other\nWHERE a = 7"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestQueryBuilder_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build *QueryBuilder
+		want  string
+	}{
+		{"missing value", NewQuery("SELECT :id"), "no value for placeholder :id"},
+		{"empty identifier", NewQuery("SELECT * FROM :t").Ident("t", ""), "invalid identifier"},
+		{"empty part", NewQuery("SELECT * FROM :t").Ident("t", "DB..T"), "invalid identifier"},
+		{"control character", NewQuery("SELECT * FROM :t").Ident("t", "T\x00"), "control character"},
+		{"unsupported type", NewQuery("SELECT :v").Arg("v", struct{}{}), "unsupported literal type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build.Build()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestQuoteLiteral(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		in   any
+		want string
+	}{
+		{nil, "NULL"},
+		{true, "TRUE"},
+		{int64(-3), "-3"},
+		{1.5, "1.5"},
+		{[]byte{0xab, 0x01}, "X'ab01'"},
+		{ts, "'2024-01-02 03:04:05 +00:00'::TIMESTAMP_TZ"},
+	}
+	for _, tt := range tests {
+		got, err := QuoteLiteral(tt.in)
+		if err != nil {
+			t.Errorf("QuoteLiteral(%v): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("QuoteLiteral(%v) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
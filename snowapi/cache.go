@@ -0,0 +1,180 @@
+package snowapi
+
+import (
+	"container/list"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// ResultCache is an in-memory LRU cache of completed query responses, shared
+// by a client through Config.ResultCache. Only synchronous, read-only
+// statements (SELECT, WITH, SHOW, DESCRIBE) are cached, keyed by the
+// whitespace-normalized statement plus its bindings, session context,
+// parameters and result format.
+//
+// Cached results are served for up to the TTL regardless of changes to the
+// underlying data, so do not cache queries whose results must be fresh or
+// that are nondeterministic (CURRENT_TIMESTAMP, RANDOM, ...). Each hit
+// returns its own copy of the response, so its fields may be changed and
+// rows appended to Data, but the rows themselves are shared: do not modify
+// their values.
+type ResultCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key       string
+	statement string
+	resp      *QueryResponse
+	expires   time.Time
+}
+
+// NewResultCache returns a cache holding up to maxEntries responses (0 for
+// no limit) for ttl each.
+func NewResultCache(ttl time.Duration, maxEntries int) *ResultCache {
+	return &ResultCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		now:        time.Now,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+// Len returns the number of cached responses, including expired ones not
+// yet evicted.
+func (rc *ResultCache) Len() int {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.ll.Len()
+}
+
+// Clear removes every cached response.
+func (rc *ResultCache) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.ll.Init()
+	rc.items = make(map[string]*list.Element)
+}
+
+// Invalidate removes every cached response for statement, whatever its
+// bindings or session context.
+func (rc *ResultCache) Invalidate(statement string) {
+	statement = normalizeStatement(statement)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for e := rc.ll.Front(); e != nil; {
+		next := e.Next()
+		if entry := e.Value.(*cacheEntry); entry.statement == statement {
+			rc.remove(e)
+		}
+		e = next
+	}
+}
+
+func (rc *ResultCache) get(key string) (*QueryResponse, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	e, ok := rc.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*cacheEntry)
+	if !rc.now().Before(entry.expires) {
+		rc.remove(e)
+		return nil, false
+	}
+	rc.ll.MoveToFront(e)
+	return cacheCopy(entry.resp), true
+}
+
+func (rc *ResultCache) put(key, statement string, resp *QueryResponse) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry := &cacheEntry{key: key, statement: statement, resp: cacheCopy(resp), expires: rc.now().Add(rc.ttl)}
+	if e, ok := rc.items[key]; ok {
+		e.Value = entry
+		rc.ll.MoveToFront(e)
+		return
+	}
+	rc.items[key] = rc.ll.PushFront(entry)
+	if rc.maxEntries > 0 && rc.ll.Len() > rc.maxEntries {
+		rc.remove(rc.ll.Back())
+	}
+}
+
+// cacheCopy returns a shallow copy of resp whose Data has no spare capacity,
+// so appending to it reallocates instead of writing into the shared array.
+func cacheCopy(resp *QueryResponse) *QueryResponse {
+	cp := *resp
+	cp.Data = resp.Data[:len(resp.Data):len(resp.Data)]
+	return &cp
+}
+
+func (rc *ResultCache) remove(e *list.Element) {
+	rc.ll.Remove(e)
+	delete(rc.items, e.Value.(*cacheEntry).key)
+}
+
+// cacheKey returns the cache key for body, or "" if it must not be cached.
+func cacheKey(body QueryRequest) string {
+	if _, multi := body.Parameters["MULTI_STATEMENT_COUNT"]; multi || !isReadOnly(body.Statement) {
+		return ""
+	}
+	keyed := body
+	keyed.Statement = normalizeStatement(body.Statement)
+	b, err := json.Marshal(keyed)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// normalizeStatement collapses runs of whitespace outside quoted literals
+// and identifiers, and drops trailing semicolons.
+func normalizeStatement(statement string) string {
+	var b strings.Builder
+	var quote rune
+	space := false
+	for _, r := range strings.TrimSpace(statement) {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case unicode.IsSpace(r):
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return strings.TrimRight(b.String(), "; ")
+}
+
+// isReadOnly reports whether statement starts with a query keyword.
+func isReadOnly(statement string) bool {
+	fields := strings.Fields(strings.TrimLeft(statement, "( \t\n"))
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "WITH", "SHOW", "DESC", "DESCRIBE":
+		return true
+	}
+	return false
+}
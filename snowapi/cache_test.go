@@ -0,0 +1,120 @@
+package snowapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResultCache(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"statementHandle":"h1","data":[["1"]]}`))
+	})
+	cache := NewResultCache(time.Minute, 10)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client.config.ResultCache = cache
+
+	for _, stmt := range []string{"SELECT * FROM t", "SELECT  *\nFROM t;"} {
+		if _, err := client.Query(stmt); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected normalized statements to share a cache entry, got %d calls", calls)
+	}
+
+	if _, err := client.Execute("SELECT * FROM t", false, &RequestOptions{Warehouse: "OTHER_WH"}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("expected session context to be part of the key, got %d calls", calls)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.Execute("INSERT INTO t VALUES (1)", false, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("expected DML to bypass the cache, got %d calls", calls)
+	}
+
+	now = now.Add(2 * time.Minute)
+	client.Query("SELECT * FROM t")
+	if calls != 5 {
+		t.Errorf("expected expired entry to be refetched, got %d calls", calls)
+	}
+
+	cache.Invalidate("SELECT * FROM t")
+	client.Query("SELECT * FROM t")
+	if calls != 6 {
+		t.Errorf("expected invalidated entry to be refetched, got %d calls", calls)
+	}
+
+	cache.Clear()
+	if cache.Len() != 0 {
+		t.Errorf("expected empty cache, got %d", cache.Len())
+	}
+}
+
+func TestResultCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewResultCache(time.Minute, 2)
+	cache.put("a", "a", &QueryResponse{})
+	cache.put("b", "b", &QueryResponse{})
+	cache.get("a")
+	cache.put("c", "c", &QueryResponse{})
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Error("expected a to be retained")
+	}
+	if cache.Len() != 2 {
+		t.Errorf("expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestNormalizeStatement(t *testing.T) {
+	tests := map[string]string{
+		"  SELECT *\n\tFROM t ;; ": "SELECT * FROM t",
+		"SELECT 'a  b'  FROM t":    "SELECT 'a  b' FROM t",
+		`SELECT "my  col" FROM t`:  `SELECT "my  col" FROM t`,
+	}
+	for in, want := range tests {
+		if got := normalizeStatement(in); got != want {
+			t.Errorf("%q: expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestResultCache_HitsAreCopies(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"statementHandle":"h1","data":[["1"],["2"]]}`))
+	})
+	client.config.ResultCache = NewResultCache(time.Minute, 10)
+
+	first, err := client.Execute("SELECT * FROM t", false, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	first.StatementHandle = "changed"
+	first.Data = first.Data[:1]
+
+	hit, err := client.Execute("SELECT * FROM t", false, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hit == first || hit.StatementHandle != "h1" || len(hit.Data) != 2 {
+		t.Fatalf("expected an unmodified copy, got handle %q with %d rows", hit.StatementHandle, len(hit.Data))
+	}
+
+	hit.Data = append(hit.Data, []any{"3"})
+	again, _ := client.Execute("SELECT * FROM t", false, nil)
+	if len(again.Data) != 2 {
+		t.Errorf("expected appends to a hit not to reach the cache, got %d rows", len(again.Data))
+	}
+}
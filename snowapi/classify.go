@@ -0,0 +1,158 @@
+package snowapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// Snowflake codes and SQLStates recognized by the Is* predicates.
+const (
+	statementRunningCode  = "333334" // asynchronous execution in progress
+	statementTimeoutCode  = "000630" // statement reached its statement or warehouse timeout
+	objectNotFoundCode    = "002003" // object does not exist or not authorized
+	jwtInvalidCode        = "390144" // JWT token is invalid
+	oauthInvalidCode      = "390303" // invalid OAuth access token
+	oauthExpiredCode      = "390318" // OAuth access token expired
+	sqlStateCanceled      = "57014"  // query canceled, including by a timeout
+	sqlStateNotFound      = "42S02"  // base table or view not found
+	sqlStateInvalidAuthID = "28000"  // invalid authorization specification
+)
+
+// IsRetryable reports whether err is transient, so the same request may
+// succeed if sent again: HTTP 429, 500, 502, 503 and 504, a statement still
+// running (code 333334, ErrStatementRunning) and transient network errors
+// (see isTransientNetError). Statement errors (HTTP 422, whatever the
+// SQLState), HTTP 408 and other server-side timeouts, authentication
+// failures, unknown hosts, certificate and TLS errors, a closed client and
+// a canceled or expired context are not retryable. Resubmitting a statement
+// is only safe with a RequestOptions.RequestID; see DedupRetry.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, ErrClientClosed):
+		return false
+	case errors.Is(err, ErrStatementRunning):
+		return true
+	}
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == statementRunningCode || isRetryableStatus(apiErr.HTTPStatus)
+	}
+	return isTransientNetError(err)
+}
+
+// isTransientNetError reports whether err is a network failure that may not
+// recur: a refused or reset connection, another dial or read error, a
+// timeout, or a connection closed before the response was complete. An
+// unknown host (*HostError, or a *net.DNSError that is not found) and
+// certificate or TLS handshake failures are configuration problems and are
+// not transient. Classification looks through the *url.Error that wraps
+// every http.Client failure to the underlying cause.
+func isTransientNetError(err error) bool {
+	var hostErr *HostError
+	if errors.As(err, &hostErr) {
+		return false
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return false
+	}
+	if isTLSError(err) {
+		return false
+	}
+	if errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "read")
+}
+
+// isTLSError reports whether err is a certificate verification or TLS
+// protocol failure.
+func isTLSError(err error) bool {
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		recordErr    tls.RecordHeaderError
+		authorityErr x509.UnknownAuthorityError
+		invalidErr   x509.CertificateInvalidError
+		hostnameErr  x509.HostnameError
+		rootsErr     x509.SystemRootsError
+	)
+	return errors.As(err, &verifyErr) || errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) || errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &rootsErr)
+}
+
+// IsAuthError reports whether err is an authentication failure: an
+// *AuthError (credentials could not be produced, or were rejected even after
+// a refresh), HTTP 401 or 403, codes 390144 (invalid JWT), 390303 and 390318
+// (invalid or expired OAuth token), or SQLState 28000. Insufficient
+// privileges are a *PrivilegeError, not an authentication failure.
+func IsAuthError(err error) bool {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return true
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case jwtInvalidCode, oauthInvalidCode, oauthExpiredCode:
+		return true
+	}
+	return isAuthStatus(apiErr.HTTPStatus) || apiErr.SQLState == sqlStateInvalidAuthID
+}
+
+// IsTimeout reports whether err is a timeout: a *TimeoutError (HTTP 408),
+// code 000630 or SQLState 57014 from a statement or warehouse timeout, a
+// network timeout, or an expired context deadline.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HTTPStatus == http.StatusRequestTimeout ||
+			apiErr.Code == statementTimeoutCode || apiErr.SQLState == sqlStateCanceled
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// IsNotFound reports whether err means something does not exist: an
+// unknown statement handle (code 000709 or HTTP 404), or a missing object
+// (code 002003 or SQLState 42S02). Snowflake also uses 002003 for objects
+// the role may not see.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.Code {
+	case statementNotFoundCode, objectNotFoundCode:
+		return true
+	}
+	return apiErr.HTTPStatus == http.StatusNotFound || apiErr.SQLState == sqlStateNotFound
+}
@@ -0,0 +1,94 @@
+package snowapi
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+type timeoutNetError struct{}
+
+func (timeoutNetError) Error() string   { return "i/o timeout" }
+func (timeoutNetError) Timeout() bool   { return true }
+func (timeoutNetError) Temporary() bool { return true }
+
+func TestErrorPredicates(t *testing.T) {
+	api := func(status int, code, sqlState string) error {
+		return statusError("", status, &QueryResponse{Code: code, SQLState: sqlState, Message: "m"})
+	}
+	// transport wraps err as http.Client.Do does.
+	transport := func(err error) error {
+		return fmt.Errorf("failed to send request: %w", &url.Error{Op: "Post", URL: "https://acct.snowflakecomputing.com/api/v2/statements", Err: err})
+	}
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	noSuchHost := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "acct.snowflakecomputing.com", IsNotFound: true}}
+	dnsFlaky := &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "server misbehaving", Name: "acct.snowflakecomputing.com", IsTemporary: true}}
+	reset := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+	unknownCA := &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}
+
+	tests := []struct {
+		name                               string
+		err                                error
+		retryable, auth, timeout, notFound bool
+	}{
+		{"nil", nil, false, false, false, false},
+		{"still running 333334", api(202, "333334", ""), true, false, false, false},
+		{"ErrStatementRunning", fmt.Errorf("statement h1: %w", ErrStatementRunning), true, false, false, false},
+		// 422 statement errors are never retryable; the SQLState or code
+		// selects IsNotFound (42S02, 002003), IsTimeout (57014, 000630) or
+		// IsAuthError (28000).
+		{"422 syntax error", api(422, "001003", "42000"), false, false, false, false},
+		{"422 object not found", api(422, "002003", "42S02"), false, false, false, true},
+		{"422 statement timeout", api(422, "000630", "57014"), false, false, true, false},
+		{"422 canceled", api(422, "000604", "57014"), false, false, true, false},
+		{"422 invalid authorization", api(422, "", "28000"), false, true, false, false},
+		{"422 insufficient privileges", api(422, "003001", "42501"), false, false, false, false},
+		// 408 is a timeout, not retryable: the statement may have run.
+		{"408 timeout", api(408, "000630", "57014"), false, false, true, false},
+		{"408 without code", api(408, "", ""), false, false, true, false},
+		{"401 rejected", &AuthError{Err: api(401, "390144", ""), HTTPStatus: 401}, false, true, false, false},
+		{"401 api error", api(401, "390318", ""), false, true, false, false},
+		{"token generation", &AuthError{Err: errors.New("bad key")}, false, true, false, false},
+		{"statement not found", api(404, "000709", "02000"), false, false, false, true},
+		{"429 throttled", api(429, "", ""), true, false, false, false},
+		{"503 unavailable", fmt.Errorf("wrapped: %w", api(503, "", "")), true, false, false, false},
+		{"connection refused", fmt.Errorf("failed to send request: %w", refused), true, false, false, false},
+		{"connection refused errno", transport(&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), true, false, false, false},
+		{"connection reset", transport(reset), true, false, false, false},
+		{"temporary DNS failure", transport(dnsFlaky), true, false, false, false},
+		{"no such host", transport(noSuchHost), false, false, false, false},
+		{"HostError", &HostError{Host: "acct.snowflakecomputing.com", Err: transport(noSuchHost)}, false, false, false, false},
+		{"unknown certificate authority", transport(unknownCA), false, false, false, false},
+		{"x509 hostname mismatch", transport(x509.HostnameError{Host: "acct.snowflakecomputing.com"}), false, false, false, false},
+		{"TLS record header", transport(tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"}), false, false, false, false},
+		{"plain error in url.Error", transport(errors.New("malformed HTTP response")), false, false, false, false},
+		{"network timeout", fmt.Errorf("poll request failed: %w", timeoutNetError{}), true, false, true, false},
+		{"unexpected EOF", io.ErrUnexpectedEOF, true, false, false, false},
+		{"context deadline", context.DeadlineExceeded, false, false, true, false},
+		{"context canceled", context.Canceled, false, false, false, false},
+		{"client closed", ErrClientClosed, false, false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf("IsRetryable = %v, want %v", got, tt.retryable)
+			}
+			if got := IsAuthError(tt.err); got != tt.auth {
+				t.Errorf("IsAuthError = %v, want %v", got, tt.auth)
+			}
+			if got := IsTimeout(tt.err); got != tt.timeout {
+				t.Errorf("IsTimeout = %v, want %v", got, tt.timeout)
+			}
+			if got := IsNotFound(tt.err); got != tt.notFound {
+				t.Errorf("IsNotFound = %v, want %v", got, tt.notFound)
+			}
+		})
+	}
+}
@@ -1,12 +1,64 @@
 package snowapi
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
 
+// testKeyPair generates a PEM-encoded RSA key pair for tests.
+func testKeyPair(t testing.TB) (priv, pub []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	priv = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pub = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return priv, pub
+}
+
+// newTestClient returns a Client whose requests are served by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	priv, pub := testKeyPair(t)
+	client, err := NewClient(Config{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		ExpireAfter: time.Minute,
+	})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	client.baseURL = srv.URL + "/api/v2/statements"
+	return client
+}
+
 // mockPoller defines the signature for a mock Poll function.
 type mockPoller func(handle string, partition int) (*QueryResponse, int, error)
 
@@ -109,3 +161,423 @@ func TestWaitUntilComplete_HTTPError(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestWaitUntilCompleteContext_Cancelled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":"333334","message":"still running"}`))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := client.WaitUntilCompleteContext(ctx, "test-handle", time.Hour, 10)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("wait did not stop promptly after cancellation")
+	}
+}
+
+func TestWaitUntilCompleteContext_CancelOnContextDone(t *testing.T) {
+	cancelled := make(chan string, 1)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			cancelled <- r.URL.Path
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":"333334","message":"still running"}`))
+	})
+	client.config.CancelOnContextDone = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.WaitUntilCompleteContext(ctx, "test-handle", time.Hour, 10)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got: %v", err)
+	}
+	select {
+	case path := <-cancelled:
+		if !strings.HasSuffix(path, "/test-handle/cancel") {
+			t.Errorf("unexpected cancel path: %s", path)
+		}
+	default:
+		t.Error("expected the statement to be cancelled")
+	}
+}
+
+func TestExecuteContext_DeadlineAbortsRequest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(500 * time.Millisecond):
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.ExecuteContext(ctx, "SELECT 1", false, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected context.DeadlineExceeded, got: %v", err)
+	}
+}
+
+func TestNewQueryRequest_StatementTimeout(t *testing.T) {
+	client := &Client{}
+
+	body, err := client.newQueryRequest("SELECT 1", nil)
+	if err != nil || body.Timeout != 60 {
+		t.Errorf("expected default timeout 60, got %d (err %v)", body.Timeout, err)
+	}
+
+	body, err = client.newQueryRequest("SELECT 1", &RequestOptions{StatementTimeout: 600})
+	if err != nil || body.Timeout != 600 {
+		t.Errorf("expected timeout 600, got %d (err %v)", body.Timeout, err)
+	}
+
+	if _, err := client.newQueryRequest("SELECT 1", &RequestOptions{StatementTimeout: -5}); err == nil {
+		t.Error("expected error for negative timeout")
+	}
+}
+
+func TestBuildBaseURL(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"default", Config{Account: "xy12345"}, "https://xy12345.snowflakecomputing.com/api/v2/statements"},
+		{"privatelink", Config{Account: "xy12345", PrivateLink: true}, "https://xy12345.privatelink.snowflakecomputing.com/api/v2/statements"},
+		{"region", Config{Account: "xy12345", Region: "east-us-2.azure"}, "https://xy12345.east-us-2.azure.snowflakecomputing.com/api/v2/statements"},
+		{"region privatelink", Config{Account: "xy12345", Region: "us-west-2", PrivateLink: true}, "https://xy12345.us-west-2.privatelink.snowflakecomputing.com/api/v2/statements"},
+		{"override host", Config{Account: "xy12345", Region: "ignored", OverrideHost: "proxy.internal"}, "https://xy12345.proxy.internal/api/v2/statements"},
+		{"region-qualified account", Config{Account: "xy12345.us-east-1"}, "https://xy12345.us-east-1.snowflakecomputing.com/api/v2/statements"},
+		{"org account", Config{Account: "myorg.myaccount"}, "https://myorg-myaccount.snowflakecomputing.com/api/v2/statements"},
+		{"base url", Config{Account: "xy12345", OverrideHost: "ignored", BaseURL: "https://gateway.example.com/snowflake/"}, "https://gateway.example.com/snowflake/api/v2/statements"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildBaseURL(tt.cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := buildBaseURL(Config{Account: "xy12345", BaseURL: "not a url"}); err == nil {
+		t.Error("expected error for invalid base URL")
+	}
+}
+
+// roundTripFunc adapts a function into an http.RoundTripper.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewClient_CustomHTTPClient(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	var gotURL string
+	client, err := NewClient(Config{
+		Account:     "TESTACCT",
+		User:        "TESTUSER",
+		PrivateKey:  priv,
+		PublicKey:   pub,
+		ExpireAfter: time.Minute,
+		HTTPTimeout: time.Nanosecond, // ignored in favor of HTTPClient
+		HTTPClient: &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			gotURL = r.URL.String()
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`{"data":[["1"]]}`)),
+			}, nil
+		})},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows, err := client.Query("SELECT 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || rows[0][0] != "1" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+	if !strings.HasPrefix(gotURL, "https://TESTACCT.snowflakecomputing.com/api/v2/statements") {
+		t.Errorf("unexpected request URL: %s", gotURL)
+	}
+}
+
+func TestNewClientWithOptions(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	logger := &recordingLogger{}
+	client, err := NewClientWithOptions("TESTACCT", "TESTUSER",
+		WithPrivateKey(priv, pub),
+		WithRole("ANALYST"),
+		WithWarehouse("COMPUTE_WH"),
+		WithDatabase("TEST_DB"),
+		WithSchema("PUBLIC"),
+		WithExpireAfter(time.Minute),
+		WithHTTPTimeout(30*time.Second),
+		WithRetryConfig(RetryConfig{MaxAttempts: 3}),
+		WithLogger(logger),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := client.config
+	if cfg.Role != "ANALYST" || cfg.Warehouse != "COMPUTE_WH" || cfg.Database != "TEST_DB" || cfg.Schema != "PUBLIC" {
+		t.Errorf("unexpected session config: %+v", cfg)
+	}
+	if client.httpClient.Timeout != 30*time.Second || cfg.Retry.MaxAttempts != 3 || client.logger != logger {
+		t.Errorf("options not applied")
+	}
+
+	if _, err := NewClientWithOptions("", "TESTUSER"); err == nil {
+		t.Error("expected error for missing account")
+	}
+}
+
+func TestNewQueryRequest_SessionContext(t *testing.T) {
+	client := &Client{config: Config{Database: "DB", Schema: "PUBLIC", Warehouse: "WH", Role: "ANALYST"}}
+
+	body, err := client.newQueryRequest("SELECT 1", &RequestOptions{Warehouse: "BIG_WH", Role: "ADMIN"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Database != "DB" || body.Schema != "PUBLIC" || body.Warehouse != "BIG_WH" || body.Role != "ADMIN" {
+		t.Errorf("unexpected session context: %+v", body)
+	}
+
+	b, err := json.Marshal(QueryRequest{Statement: "SELECT 1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, key := range []string{"database", "schema", "warehouse", "role"} {
+		if strings.Contains(string(b), key) {
+			t.Errorf("expected empty %s to be omitted: %s", key, b)
+		}
+	}
+}
+
+func TestExecute_NullableParam(t *testing.T) {
+	var got string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query().Get("nullable")
+		w.Write([]byte(`{"statementHandle":"h1"}`))
+	})
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil || got != "true" {
+		t.Errorf("expected nullable=true by default, got %q (err %v)", got, err)
+	}
+	off := false
+	if _, err := client.Execute("SELECT 1", false, &RequestOptions{Nullable: &off}); err != nil || got != "false" {
+		t.Errorf("expected nullable=false, got %q (err %v)", got, err)
+	}
+}
+
+func TestExecute_DedupRetry(t *testing.T) {
+	var query url.Values
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		query = r.URL.Query()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	client.config.Retry = RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	on, off := true, false
+	tests := []struct {
+		name      string
+		opts      *RequestOptions
+		requestID string
+		retry     string
+		attempts  int
+	}{
+		{"no requestId", nil, "", "", 1},
+		{"requestId defaults to dedup", &RequestOptions{RequestID: "r1"}, "r1", "true", 2},
+		{"requestId without dedup", &RequestOptions{RequestID: "r1", DedupRetry: &off}, "r1", "", 1},
+		{"deprecated Retry still honored", &RequestOptions{RequestID: "r1", Retry: &off}, "r1", "", 1},
+		{"DedupRetry overrides Retry", &RequestOptions{RequestID: "r1", Retry: &off, DedupRetry: &on}, "r1", "true", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = 0
+			client.Execute("SELECT 1", false, tt.opts)
+			if got := query.Get("requestId"); got != tt.requestID {
+				t.Errorf("requestId = %q, want %q", got, tt.requestID)
+			}
+			if got := query.Get("retry"); got != tt.retry {
+				t.Errorf("retry = %q, want %q", got, tt.retry)
+			}
+			if calls != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, calls)
+			}
+		})
+	}
+
+	calls = 0
+	_, err := client.Execute("SELECT 1", false, &RequestOptions{DedupRetry: &on})
+	if err == nil || !strings.Contains(err.Error(), "requires a RequestID") || calls != 0 {
+		t.Errorf("expected DedupRetry without RequestID to be rejected, got %v after %d calls", err, calls)
+	}
+}
+
+func TestRequestHeaders(t *testing.T) {
+	var got http.Header
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"statementHandle":"h1"}`))
+	})
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ua := got.Get("User-Agent"); ua != "gosnowapi/"+Version {
+		t.Errorf("unexpected default User-Agent %q", ua)
+	}
+
+	client.config.UserAgent = "myapp/1.2"
+	client.config.Headers = map[string]string{"X-Proxy-Tenant": "acme"}
+	if _, _, err := client.Poll("h1", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ua := got.Get("User-Agent"); ua != "myapp/1.2 gosnowapi/"+Version {
+		t.Errorf("unexpected User-Agent %q", ua)
+	}
+	if got.Get("X-Proxy-Tenant") != "acme" || !strings.HasPrefix(got.Get("Authorization"), "Bearer ") {
+		t.Errorf("unexpected headers: %v", got)
+	}
+
+	for _, name := range []string{"Authorization", "content-type"} {
+		_, err := NewClientWithOptions("TESTACCT", "TESTUSER", WithHeader(name, "x"))
+		if err == nil || !strings.Contains(err.Error(), "cannot be overridden") {
+			t.Errorf("expected %s to be rejected, got %v", name, err)
+		}
+	}
+}
+
+func TestNewQueryRequest_Parameters(t *testing.T) {
+	client := &Client{config: Config{Parameters: map[string]string{"TIMEZONE": "UTC", "query_tag": "etl"}}}
+
+	body, err := client.newQueryRequest("SELECT 1", &RequestOptions{Parameters: map[string]string{"timezone": "America/New_York"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"TIMEZONE": "America/New_York", "QUERY_TAG": "etl"}
+	if !reflect.DeepEqual(body.Parameters, want) {
+		t.Errorf("expected %v, got %v", want, body.Parameters)
+	}
+	// TIMEZONE is set by both; the per-request value takes precedence.
+	if got := body.Parameters["TIMEZONE"]; got != "America/New_York" {
+		t.Errorf("expected the request's TIMEZONE to override the default UTC, got %q", got)
+	}
+
+	b, _ := json.Marshal(body)
+	if !strings.Contains(string(b), `"parameters":{"QUERY_TAG":"etl","TIMEZONE":"America/New_York"}`) {
+		t.Errorf("parameters not serialized: %s", b)
+	}
+
+	body, err = client.newQueryRequest("SELECT 1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]string{"TIMEZONE": "UTC", "QUERY_TAG": "etl"}; !reflect.DeepEqual(body.Parameters, want) {
+		t.Errorf("expected the defaults %v without overrides, got %v", want, body.Parameters)
+	}
+
+	if body, _ := (&Client{}).newQueryRequest("SELECT 1", nil); body.Parameters != nil {
+		t.Errorf("expected no parameters, got %v", body.Parameters)
+	}
+	if _, err := client.newQueryRequest("SELECT 1", &RequestOptions{Parameters: map[string]string{"BAD NAME": "x"}}); err == nil {
+		t.Error("expected error for invalid parameter name")
+	}
+}
+
+func TestNewClient_InvalidParameters(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	_, err := NewClient(Config{Account: "A", User: "U", PrivateKey: priv, PublicKey: pub, Parameters: map[string]string{"TIME ZONE": "UTC"}})
+	if err == nil || !strings.Contains(err.Error(), "invalid session parameter name") {
+		t.Errorf("expected an invalid parameter error, got %v", err)
+	}
+}
+
+func TestNewClient_ValidatesKeyMaterial(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	base := Config{Account: "TESTACCT", User: "TESTUSER", PrivateKey: priv, PublicKey: pub}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{"valid", func(c *Config) {}, ""},
+		{"bad private key", func(c *Config) { c.PrivateKey = []byte("garbage") }, "invalid private key"},
+		{"bad public key", func(c *Config) { c.PublicKey = []byte("garbage") }, "invalid public key"},
+		{"derived public key", func(c *Config) { c.PublicKey = nil }, ""},
+		{"mismatched public key", func(c *Config) { _, c.PublicKey = testKeyPair(t) }, "does not match"},
+		{"negative expiry", func(c *Config) { c.ExpireAfter = -time.Minute }, "must be positive"},
+		{"expiry too long", func(c *Config) { c.ExpireAfter = 2 * time.Hour }, "exceeds"},
+		{"skew shrinks default expiry", func(c *Config) { c.IssuedAtSkew = 30 * time.Second }, ""},
+		{"expiry plus skew too long", func(c *Config) { c.ExpireAfter, c.IssuedAtSkew = time.Hour, time.Second }, "exceeds"},
+		{"negative skew", func(c *Config) { c.IssuedAtSkew = -time.Second }, "IssuedAtSkew"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.modify(&cfg)
+			_, err := NewClient(cfg)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestExecute_MinimalMetadata(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		w.Write([]byte(`{"code":"090001","resultSetMetaData":{"numRows":1,"rowType":[{"name":"ID","type":"fixed"}],"partitionInfo":[{"rowCount":1}]},"data":[["1"]]}`))
+	})
+
+	full, err := client.Execute("SELECT 1", false, &RequestOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	minimal, err := client.Execute("SELECT 1", false, &RequestOptions{MinimalMetadata: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] {
+		t.Errorf("expected identical requests, since the SQL API cannot omit metadata:\n%s\n%s", bodies[0], bodies[1])
+	}
+	if len(full.ResultSetMetaData.RowType) != 1 {
+		t.Errorf("expected RowType without MinimalMetadata, got %+v", full.ResultSetMetaData)
+	}
+	if minimal.ResultSetMetaData.RowType != nil {
+		t.Errorf("expected RowType to be dropped, got %+v", minimal.ResultSetMetaData.RowType)
+	}
+	if minimal.ResultSetMetaData.NumRows != 1 || len(minimal.ResultSetMetaData.PartitionInfo) != 1 || len(minimal.Data) != 1 {
+		t.Errorf("expected the rest of the response to be kept, got %+v", minimal)
+	}
+}
@@ -0,0 +1,26 @@
+package snowapi
+
+import "errors"
+
+// ErrClientClosed is returned by calls on a Client after Close.
+var ErrClientClosed = errors.New("snowapi: client is closed")
+
+// Close marks the client unusable and closes its idle HTTP connections.
+// Later calls fail with ErrClientClosed; requests already in flight run to
+// completion. A Config.ResultCache is left intact since it may be shared.
+// Close is idempotent and safe to call concurrently.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.closed.Store(true)
+		c.httpClient.CloseIdleConnections()
+	})
+	return nil
+}
+
+// checkOpen returns ErrClientClosed once Close has been called.
+func (c *Client) checkOpen() error {
+	if c.closed.Load() {
+		return ErrClientClosed
+	}
+	return nil
+}
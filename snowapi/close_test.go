@@ -0,0 +1,44 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestClose(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"code":"090001","statementHandle":"h1","data":[["1"]]}`))
+	})
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Close(); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := client.Query("SELECT 1"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed from Query, got %v", err)
+	}
+	if _, _, err := client.Poll("h1", 0); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed from Poll, got %v", err)
+	}
+	if err := client.Cancel("h1"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("expected ErrClientClosed from Cancel, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected no requests after Close, got %d", calls-1)
+	}
+}
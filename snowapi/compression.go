@@ -0,0 +1,69 @@
+package snowapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// gzipBody compresses b when a threshold is configured and b exceeds it,
+// reporting whether it did.
+func (c *Client) gzipBody(b []byte) ([]byte, bool, error) {
+	threshold := c.config.GzipRequestThreshold
+	if threshold <= 0 || len(b) <= threshold {
+		return b, false, nil
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false, fmt.Errorf("failed to compress request body: %w", err)
+	}
+	return buf.Bytes(), true, nil
+}
+
+// gunzipResponse swaps a gzip-encoded response body for a decompressing
+// reader. Requests set Accept-Encoding explicitly, so the transport leaves
+// decompression to us.
+func gunzipResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+	if resp.ContentLength == 0 {
+		// An empty body (e.g. a 204) has no gzip header to read.
+		resp.Header.Del("Content-Encoding")
+		return nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	switch {
+	case err == io.EOF:
+		resp.Body.Close()
+		resp.Body = http.NoBody
+	case err != nil:
+		resp.Body.Close()
+		return fmt.Errorf("failed to decompress response: %w", err)
+	default:
+		resp.Body = &gzipReadCloser{Reader: zr, body: resp.Body}
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipReadCloser closes both the gzip reader and the underlying body.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (g *gzipReadCloser) Close() error {
+	g.Reader.Close()
+	return g.body.Close()
+}
@@ -0,0 +1,105 @@
+package snowapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func gzipBytes(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	zw.Write([]byte(s))
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestGzipResponse(t *testing.T) {
+	body := gzipBytes(t, `{"statementHandle":"h1","data":[["1"],["2"]]}`)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("expected Accept-Encoding: gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body)
+	})
+
+	resp, err := client.Execute("SELECT 1", false, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data) != 2 {
+		t.Errorf("expected 2 rows, got %d", len(resp.Data))
+	}
+
+	polled, status, err := client.Poll("h1", 1)
+	if err != nil || status != http.StatusOK || polled.StatementHandle != "h1" {
+		t.Errorf("unexpected poll result: %+v %d (err %v)", polled, status, err)
+	}
+}
+
+func TestGzipRequestThreshold(t *testing.T) {
+	var encodings []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("invalid gzip body: %v", err)
+			}
+			reader = zr
+		}
+		var body QueryRequest
+		if err := json.NewDecoder(reader).Decode(&body); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		w.Write([]byte(`{"statementHandle":"h1"}`))
+	})
+	client.config.GzipRequestThreshold = 256
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	big := "INSERT INTO t VALUES " + strings.Repeat("(1, 'x'), ", 100) + "(1, 'x')"
+	if _, err := client.Execute(big, false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Errorf("expected only the large body to be compressed, got %q", encodings)
+	}
+}
+
+func TestGzipResponse_EmptyBody(t *testing.T) {
+	for _, length := range []int64{0, -1} {
+		resp := &http.Response{
+			StatusCode:    http.StatusNoContent,
+			Header:        http.Header{"Content-Encoding": {"gzip"}},
+			Body:          io.NopCloser(strings.NewReader("")),
+			ContentLength: length,
+		}
+		if err := gunzipResponse(resp); err != nil {
+			t.Fatalf("length %d: unexpected error: %v", length, err)
+		}
+		if b, err := io.ReadAll(resp.Body); err != nil || len(b) != 0 {
+			t.Errorf("length %d: expected an empty body, got %q (err %v)", length, b, err)
+		}
+		if resp.Header.Get("Content-Encoding") != "" {
+			t.Errorf("length %d: expected Content-Encoding to be removed", length)
+		}
+	}
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+	})
+	if err := client.Cancel("h1"); err != nil {
+		t.Errorf("expected an empty gzip response to be accepted, got %v", err)
+	}
+}
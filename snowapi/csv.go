@@ -0,0 +1,95 @@
+package snowapi
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WriteCSV writes a header of column names followed by every row in Data.
+// NULLs become empty fields, timestamps are RFC 3339, dates are YYYY-MM-DD and
+// fixed-point numbers keep their column scale. To export a multi-partition
+// result, set Data to the rows returned by FetchAllPartitions first.
+func (r *QueryResponse) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(r.ColumnNames()); err != nil {
+		return err
+	}
+
+	cols := r.ResultSetMetaData.RowType
+	record := make([]string, len(cols))
+	for i, row := range r.Data {
+		for j := range record {
+			record[j] = ""
+			if j >= len(row) {
+				continue
+			}
+			field, err := formatCSVValue(row[j], cols[j])
+			if err != nil {
+				return fmt.Errorf("row %d, column %s: %w", i, cols[j].Name, err)
+			}
+			record[j] = field
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ToCSVString is like WriteCSV but returns the CSV as a string.
+func (r *QueryResponse) ToCSVString() (string, error) {
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// formatCSVValue renders a single value for CSV output.
+func formatCSVValue(raw any, col ColumnMeta) (string, error) {
+	if raw == nil {
+		return "", nil
+	}
+
+	switch strings.ToLower(col.Type) {
+	case "fixed":
+		// The json format already carries the exact scale.
+		if s, ok := raw.(string); ok {
+			return s, nil
+		}
+	case "binary", "variant", "object", "array":
+		if s, ok := raw.(string); ok {
+			return s, nil
+		}
+	}
+
+	v, err := DecodeValue(raw, col)
+	if err != nil {
+		return "", err
+	}
+
+	switch x := v.(type) {
+	case time.Time:
+		switch strings.ToLower(col.Type) {
+		case "date":
+			return x.Format("2006-01-02"), nil
+		case "time":
+			return x.Format("15:04:05.999999999"), nil
+		}
+		return x.Format(time.RFC3339Nano), nil
+	case float64:
+		if col.Scale != nil && strings.EqualFold(col.Type, "fixed") {
+			return strconv.FormatFloat(x, 'f', *col.Scale, 64), nil
+		}
+		return strconv.FormatFloat(x, 'g', -1, 64), nil
+	default:
+		return fmt.Sprint(x), nil
+	}
+}
@@ -0,0 +1,32 @@
+package snowapi
+
+import "testing"
+
+func TestQueryResponse_ToCSVString(t *testing.T) {
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{
+			RowType: []ColumnMeta{
+				{Name: "ID", Type: "fixed", Scale: intPtr(0)},
+				{Name: "NAME", Type: "text"},
+				{Name: "PRICE", Type: "fixed", Scale: intPtr(2)},
+				{Name: "CREATED_AT", Type: "timestamp_ntz"},
+				{Name: "DAY", Type: "date"},
+			},
+		},
+		Data: [][]any{
+			{"1", `say "hi", ok`, "12.50", "1710498645.000000000", "19797"},
+			{"2", nil, float64(3), nil, nil},
+		},
+	}
+
+	got, err := resp.ToCSVString()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "ID,NAME,PRICE,CREATED_AT,DAY\n" +
+		"1,\"say \"\"hi\"\", ok\",12.50,2024-03-15T10:30:45Z,2024-03-15\n" +
+		"2,,3.00,,\n"
+	if got != want {
+		t.Errorf("unexpected CSV:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,141 @@
+package snowapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// DebugBodyLimit is how much of each request and response body
+// Config.DebugHTTP logs; longer bodies are truncated.
+const DebugBodyLimit = 4096
+
+// debugPeekLimit bounds the compressed bytes read ahead of the body to
+// decompress the logged prefix of a gzip response.
+const debugPeekLimit = 64 << 10
+
+// secretJSONField matches JSON string members whose names suggest a
+// credential, so their values can be masked in dumped bodies.
+var secretJSONField = regexp.MustCompile(`(?i)("[^"]*(?:token|password|secret|private_?key)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// dumpRequest logs req when Config.DebugHTTP is set. The body is read
+// through req.GetBody, so req itself is left untouched.
+func (c *Client) dumpRequest(req *http.Request) {
+	if !c.config.DebugHTTP {
+		return
+	}
+	clone := req.Clone(req.Context())
+	// DumpRequestOut swaps in a placeholder of ContentLength bytes when not
+	// dumping the body, but only for a non-nil Body.
+	clone.Body = io.NopCloser(bytes.NewReader(nil))
+	clone.Header = redactHeaders(req.Header)
+	if u, err := url.Parse(redactURL(req.URL)); err == nil {
+		clone.URL = u
+	}
+	head, err := httputil.DumpRequestOut(clone, false)
+	if err != nil {
+		c.logger.Debugf("snowapi: failed to dump request: %v", err)
+		return
+	}
+
+	var body []byte
+	truncated := false
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, truncated = readDebugBody(rc, req.Header.Get("Content-Encoding"))
+			rc.Close()
+		}
+	}
+	c.logger.Debugf("snowapi: HTTP request:\n%s", formatDump(head, body, truncated))
+}
+
+// dumpResponse logs resp when Config.DebugHTTP is set. It reads ahead a
+// prefix of the body and puts it back, so resp still streams in full.
+func (c *Client) dumpResponse(resp *http.Response) {
+	if !c.config.DebugHTTP {
+		return
+	}
+	shallow := *resp
+	shallow.Body = nil
+	shallow.Header = redactHeaders(resp.Header)
+	head, err := httputil.DumpResponse(&shallow, false)
+	if err != nil {
+		c.logger.Debugf("snowapi: failed to dump response: %v", err)
+		return
+	}
+
+	encoding := resp.Header.Get("Content-Encoding")
+	peek := DebugBodyLimit + 1
+	if strings.EqualFold(encoding, "gzip") {
+		peek = debugPeekLimit
+	}
+	prefix := make([]byte, peek)
+	n, _ := io.ReadFull(resp.Body, prefix)
+	prefix = prefix[:n]
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+
+	body, truncated := readDebugBody(io.NopCloser(bytes.NewReader(prefix)), encoding)
+	if n == peek {
+		truncated = true
+	}
+	c.logger.Debugf("snowapi: HTTP response:\n%s", formatDump(head, body, truncated))
+}
+
+// readDebugBody returns up to DebugBodyLimit bytes of r, decompressed when
+// encoding is gzip, and whether there was more. A gzip stream cut short
+// still yields the bytes decoded before the cut.
+func readDebugBody(r io.Reader, encoding string) ([]byte, bool) {
+	if strings.EqualFold(encoding, "gzip") {
+		zr, err := gzip.NewReader(r)
+		if err != nil {
+			return []byte("[undecodable gzip body]"), false
+		}
+		defer zr.Close()
+		r = zr
+	}
+	body, _ := io.ReadAll(io.LimitReader(r, DebugBodyLimit+1))
+	if len(body) > DebugBodyLimit {
+		return body[:DebugBodyLimit], true
+	}
+	return body, false
+}
+
+// formatDump joins a dumped head with a redacted body.
+func formatDump(head, body []byte, truncated bool) string {
+	var b strings.Builder
+	b.Write(head)
+	b.Write(secretJSONField.ReplaceAll(body, []byte(`$1"REDACTED"`)))
+	if truncated {
+		b.WriteString("\n[body truncated]")
+	}
+	return b.String()
+}
+
+// redactHeaders returns a copy of h with credential headers masked.
+func redactHeaders(h http.Header) http.Header {
+	clean := h.Clone()
+	for name := range clean {
+		if isSecretHeader(name) {
+			clean.Set(name, "REDACTED")
+		}
+	}
+	return clean
+}
+
+func isSecretHeader(name string) bool {
+	switch lower := strings.ToLower(name); lower {
+	case "authorization", "proxy-authorization", "cookie", "set-cookie":
+		return true
+	default:
+		return strings.Contains(lower, "secret") || strings.Contains(lower, "password") ||
+			strings.Contains(lower, "api-key") || strings.Contains(lower, "apikey")
+	}
+}
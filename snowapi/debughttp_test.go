@@ -0,0 +1,118 @@
+package snowapi
+
+import (
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDebugHTTP_DumpsWithoutToken(t *testing.T) {
+	var token string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"code":"090001","statementHandle":"handle-123","data":[["1"]]}`))
+	})
+	logger := &recordingLogger{}
+	client.logger = logger
+	client.config.DebugHTTP = true
+
+	rows, err := client.Query("SELECT 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Errorf("expected the response to survive the dump, got %v", rows)
+	}
+
+	out := logger.String()
+	for _, want := range []string{
+		"POST /api/v2/statements",
+		"Authorization: REDACTED",
+		`"statement":"SELECT 1"`,
+		"HTTP/1.1 200 OK",
+		`"statementHandle":"handle-123"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected dump to contain %q:\n%s", want, out)
+		}
+	}
+	if token == "" || strings.Contains(out, token) {
+		t.Errorf("bearer token leaked into the dump")
+	}
+}
+
+func TestDebugHTTP_TruncatesGzipBody(t *testing.T) {
+	const rows = 5000
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		fmt.Fprint(zw, `{"code":"090001","data":[`)
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				fmt.Fprint(zw, ",")
+			}
+			fmt.Fprintf(zw, `["row-%d"]`, i)
+		}
+		fmt.Fprint(zw, `]}`)
+		zw.Close()
+	})
+	logger := &recordingLogger{}
+	client.logger = logger
+	client.config.DebugHTTP = true
+
+	data, err := client.Query("SELECT * FROM big")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != rows {
+		t.Errorf("expected %d rows after the dump, got %d", rows, len(data))
+	}
+
+	out := logger.String()
+	if !strings.Contains(out, `["row-0"]`) || !strings.Contains(out, "[body truncated]") {
+		t.Errorf("expected a decompressed, truncated body in the dump:\n%.500s", out)
+	}
+	if strings.Contains(out, fmt.Sprintf(`"row-%d"`, rows-1)) {
+		t.Error("expected the dump to stop at DebugBodyLimit")
+	}
+}
+
+func TestDebugHTTP_Disabled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	logger := &recordingLogger{}
+	client.logger = logger
+
+	if _, err := client.Query("SELECT 1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(logger.String(), "HTTP request") {
+		t.Errorf("unexpected dump without DebugHTTP:\n%s", logger.String())
+	}
+}
+
+func TestFormatDump_RedactsSecrets(t *testing.T) {
+	body := []byte(`{"access_token":"abc","Password":"p\"w","masterToken":"m","statement":"SELECT 1"}`)
+	got := formatDump([]byte("HEAD\r\n\r\n"), body, false)
+	for _, secret := range []string{"abc", `p\"w`, `"m"`} {
+		if strings.Contains(got, secret) {
+			t.Errorf("dump leaks %s: %s", secret, got)
+		}
+	}
+	if !strings.Contains(got, `"statement":"SELECT 1"`) {
+		t.Errorf("expected non-secret fields to be kept: %s", got)
+	}
+
+	h := http.Header{"Authorization": {"Bearer x"}, "X-Api-Key": {"k"}, "X-Snowflake-Authorization-Token-Type": {"KEYPAIR_JWT"}}
+	clean := redactHeaders(h)
+	if clean.Get("Authorization") != "REDACTED" || clean.Get("X-Api-Key") != "REDACTED" {
+		t.Errorf("expected credential headers to be masked: %v", clean)
+	}
+	if clean.Get("X-Snowflake-Authorization-Token-Type") != "KEYPAIR_JWT" || h.Get("Authorization") != "Bearer x" {
+		t.Errorf("unexpected header changes: %v, original %v", clean, h)
+	}
+}
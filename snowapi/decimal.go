@@ -0,0 +1,184 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// maxExactFloatDigits is the number of significant decimal digits a float64
+// is guaranteed to round-trip. FIXED columns with a scale and a larger
+// precision decode to Decimal rather than float64.
+const maxExactFloatDigits = 15
+
+var decimalType = reflect.TypeOf(Decimal{})
+
+// Decimal is an exact fixed-point number: an arbitrary-precision unscaled
+// integer and a count of fractional digits. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int
+}
+
+// ParseDecimal parses a plain decimal string such as "-123.4500". The scale
+// of the result is the number of digits after the point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	intPart, fracPart, _ := strings.Cut(digits, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	unscaled, ok := new(big.Int).SetString(intPart+fracPart, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if strings.HasPrefix(s, "-") {
+		unscaled.Neg(unscaled)
+	}
+	return Decimal{unscaled: unscaled, scale: len(fracPart)}, nil
+}
+
+// ScanDecimal decodes a FIXED column value into a Decimal carrying the
+// column's scale, failing if the value does not fit its precision.
+func ScanDecimal(raw any, col ColumnMeta) (Decimal, error) {
+	var s string
+	switch v := raw.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return Decimal{}, fmt.Errorf("cannot scan NULL into Decimal")
+	default:
+		return Decimal{}, fmt.Errorf("cannot scan %T into Decimal", raw)
+	}
+
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	if col.Scale != nil {
+		if d, err = d.rescale(*col.Scale); err != nil {
+			return Decimal{}, err
+		}
+	}
+	if col.Precision != nil && d.digits() > *col.Precision {
+		return Decimal{}, fmt.Errorf("decimal %s exceeds precision %d", s, *col.Precision)
+	}
+	return d, nil
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Rat returns the exact value as a big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(d.int(), pow10(d.scale))
+}
+
+// Float64 returns the nearest float64, which may lose precision.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Int64 returns d as an int64, reporting false if it has a non-zero
+// fractional part or does not fit.
+func (d Decimal) Int64() (int64, bool) {
+	q, r := new(big.Int).QuoRem(d.int(), pow10(d.scale), new(big.Int))
+	if r.Sign() != 0 || !q.IsInt64() {
+		return 0, false
+	}
+	return q.Int64(), true
+}
+
+// Cmp compares d and other numerically, returning -1, 0 or +1.
+func (d Decimal) Cmp(other Decimal) int {
+	return d.Rat().Cmp(other.Rat())
+}
+
+// String formats d with exactly Scale() fractional digits.
+func (d Decimal) String() string {
+	n := d.int()
+	abs := new(big.Int).Abs(n).String()
+	if d.scale > 0 {
+		if len(abs) <= d.scale {
+			abs = strings.Repeat("0", d.scale-len(abs)+1) + abs
+		}
+		abs = abs[:len(abs)-d.scale] + "." + abs[len(abs)-d.scale:]
+	}
+	if n.Sign() < 0 {
+		return "-" + abs
+	}
+	return abs
+}
+
+// MarshalJSON encodes d as a bare JSON number, keeping every digit.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalText encodes d as its String form.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// int returns the unscaled value, treating the zero Decimal as 0.
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// digits returns the number of significant digits of the unscaled value.
+func (d Decimal) digits() int {
+	return len(new(big.Int).Abs(d.int()).String())
+}
+
+// rescale changes the scale, refusing to drop non-zero fractional digits.
+func (d Decimal) rescale(scale int) (Decimal, error) {
+	switch {
+	case scale == d.scale:
+		return d, nil
+	case scale > d.scale:
+		n := new(big.Int).Mul(d.int(), pow10(scale-d.scale))
+		return Decimal{unscaled: n, scale: scale}, nil
+	}
+	q, r := new(big.Int).QuoRem(d.int(), pow10(d.scale-scale), new(big.Int))
+	if r.Sign() != 0 {
+		return Decimal{}, fmt.Errorf("decimal %s has more than %d fractional digits", d, scale)
+	}
+	return Decimal{unscaled: q, scale: scale}, nil
+}
+
+// wantsDecimal reports whether a FIXED column with a scale cannot be
+// represented exactly by float64. Scale-0 columns decode to int64 and fall
+// back to Decimal only for values outside its range.
+func wantsDecimal(col ColumnMeta) bool {
+	if col.Precision == nil || col.Scale == nil || *col.Scale == 0 {
+		return false
+	}
+	return *col.Precision > maxExactFloatDigits
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := map[string]string{
+		"12345678901234567890.123456789": "12345678901234567890.123456789",
+		"-0.05":                          "-0.05",
+		"+7":                             "7",
+		".5":                             "0.5",
+	}
+	for in, want := range tests {
+		d, err := ParseDecimal(in)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", in, err)
+			continue
+		}
+		if d.String() != want {
+			t.Errorf("%s: expected %s, got %s", in, want, d)
+		}
+	}
+
+	for _, bad := range []string{"", "-", "1.2.3", "1e5", "abc"} {
+		if _, err := ParseDecimal(bad); err == nil {
+			t.Errorf("%q: expected error", bad)
+		}
+	}
+
+	var zero Decimal
+	if zero.String() != "0" || zero.Float64() != 0 {
+		t.Errorf("unexpected zero value: %s", zero)
+	}
+}
+
+func TestScanDecimal_RespectsScaleAndPrecision(t *testing.T) {
+	col := ColumnMeta{Type: "fixed", Scale: intPtr(4), Precision: intPtr(10)}
+
+	d, err := ScanDecimal("12.5", col)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.String() != "12.5000" || d.Scale() != 4 {
+		t.Errorf("expected 12.5000, got %s", d)
+	}
+
+	if _, err := ScanDecimal("1.23456", col); err == nil {
+		t.Error("expected error for too many fractional digits")
+	}
+	if _, err := ScanDecimal("1234567.0", col); err == nil {
+		t.Error("expected error for exceeding precision")
+	}
+}
+
+func TestDecodeValue_HighPrecisionDecimal(t *testing.T) {
+	col := ColumnMeta{Type: "fixed", Scale: intPtr(9), Precision: intPtr(38)}
+	raw := "12345678901234567890.123456789"
+
+	v, err := DecodeValue(raw, col)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d, ok := v.(Decimal)
+	if !ok {
+		t.Fatalf("expected Decimal, got %T", v)
+	}
+	if d.String() != raw {
+		t.Errorf("expected %s, got %s", raw, d)
+	}
+
+	want, _ := new(big.Rat).SetString(raw)
+	if d.Rat().Cmp(want) != 0 {
+		t.Errorf("expected exact value %s, got %s", want, d.Rat())
+	}
+}
+
+func TestScanInto_Decimal(t *testing.T) {
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{
+			{Name: "AMOUNT", Type: "fixed", Scale: intPtr(9), Precision: intPtr(38)},
+			{Name: "PRICE", Type: "fixed", Scale: intPtr(2), Precision: intPtr(10)},
+		}},
+		Data: [][]any{{"12345678901234567890.123456789", "19.99"}},
+	}
+
+	type row struct {
+		Amount Decimal
+		Price  *Decimal
+	}
+	var rows []row
+	if err := resp.ScanInto(&rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0].Amount.String() != "12345678901234567890.123456789" {
+		t.Errorf("precision lost: %s", rows[0].Amount)
+	}
+	if rows[0].Price == nil || rows[0].Price.String() != "19.99" {
+		t.Errorf("unexpected price: %v", rows[0].Price)
+	}
+}
+
+func TestDecodeValue_IntegerBeyondInt64(t *testing.T) {
+	const huge = "99999999999999999999"
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{
+			{Name: "HASH", Type: "fixed", Scale: intPtr(0)},
+			{Name: "ID", Type: "fixed", Scale: intPtr(0)},
+		}},
+		Data: [][]any{{huge, "7"}},
+	}
+
+	records, err := resp.ToRecords()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d, ok := records[0]["HASH"].(Decimal); !ok || d.String() != huge {
+		t.Errorf("expected Decimal %s, got %T %v", huge, records[0]["HASH"], records[0]["HASH"])
+	}
+	if id := records[0]["ID"]; id != int64(7) {
+		t.Errorf("expected int64 7, got %T %v", id, id)
+	}
+
+	type row struct {
+		Hash Decimal
+		ID   int64
+	}
+	var rows []row
+	if err := resp.ScanInto(&rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rows[0].Hash.String() != huge || rows[0].ID != 7 {
+		t.Errorf("unexpected row %+v", rows[0])
+	}
+
+	var text string
+	var id int64
+	if err := scanRow(resp.Data[0], resp.Columns(), []any{&text, &id}); err != nil || text != huge {
+		t.Errorf("expected the raw text %s, got %q (err %v)", huge, text, err)
+	}
+	if err := scanRow(resp.Data[0], resp.Columns(), []any{&id, &id}); err == nil || !strings.Contains(err.Error(), "overflows int64") {
+		t.Errorf("expected an overflow error scanning into int64, got %v", err)
+	}
+}
+
+func TestDecimal_Int64(t *testing.T) {
+	for s, want := range map[string]bool{"42": true, "-42.000": true, "1.5": false, "99999999999999999999": false} {
+		d, err := ParseDecimal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := d.Int64(); ok != want {
+			t.Errorf("%s: Int64 ok = %v, want %v", s, ok, want)
+		}
+	}
+}
+
+func TestDecodeValue_HighPrecisionInteger(t *testing.T) {
+	col := ColumnMeta{Name: "ID", Type: "fixed", Scale: intPtr(0), Precision: intPtr(38)}
+	if v, err := DecodeValue("42", col); err != nil || v != int64(42) {
+		t.Errorf("expected int64 42 for NUMBER(38,0), got %T %v (err %v)", v, v, err)
+	}
+	v, err := DecodeValue("99999999999999999999", col)
+	if d, ok := v.(Decimal); !ok || d.String() != "99999999999999999999" || err != nil {
+		t.Errorf("expected Decimal beyond int64, got %T %v (err %v)", v, v, err)
+	}
+
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{{Name: "number of rows inserted", Type: "fixed", Scale: intPtr(0), Precision: intPtr(38)}}},
+		Data:              [][]any{{"3"}},
+	}
+	if n, err := resp.RowsAffected(); err != nil || n != 3 {
+		t.Errorf("expected RowsAffected 3, got %d (err %v)", n, err)
+	}
+}
+
+func TestDecimal_Marshal(t *testing.T) {
+	d, err := ParseDecimal("-12345678901234567890.50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(map[string]any{"v": d, "p": &d})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"p":-12345678901234567890.50,"v":-12345678901234567890.50}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+
+	var zero Decimal
+	if b, err := json.Marshal(zero); err != nil || string(b) != "0" {
+		t.Errorf("expected the zero Decimal to encode as 0, got %s (err %v)", b, err)
+	}
+	if b, err := d.MarshalText(); err != nil || string(b) != d.String() {
+		t.Errorf("unexpected text %s (err %v)", b, err)
+	}
+}
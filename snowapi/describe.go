@@ -0,0 +1,30 @@
+package snowapi
+
+import "context"
+
+// Describe returns the result column metadata of a query without fetching
+// its rows. The statement is run wrapped as
+//
+//	SELECT * FROM (<statement>) LIMIT 0
+//
+// so Snowflake compiles and plans it but returns no data. This only works
+// for statements valid as a subquery (SELECT, WITH ..., VALUES); DML, DDL
+// and SHOW statements fail with a SQL compilation error, and warehouse time
+// is still billed for compilation.
+func (c *Client) Describe(statement string) ([]ColumnMeta, error) {
+	return c.DescribeContext(context.Background(), statement)
+}
+
+// DescribeContext is like Describe but honors ctx.
+func (c *Client) DescribeContext(ctx context.Context, statement string) ([]ColumnMeta, error) {
+	resp, err := c.queryCompleted(ctx, describeStatement(statement))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Columns(), nil
+}
+
+// describeStatement wraps statement in a zero-row outer query.
+func describeStatement(statement string) string {
+	return limitStatement(statement, 0)
+}
@@ -0,0 +1,28 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	var statement string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body QueryRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		statement = body.Statement
+		w.Write([]byte(`{"resultSetMetaData":{"rowType":[{"name":"ID","type":"fixed","nullable":false},{"name":"NAME","type":"text","nullable":true}]},"data":[]}`))
+	})
+
+	cols, err := client.Describe("SELECT id, name FROM users;")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statement != "SELECT * FROM (\nSELECT id, name FROM users\n) LIMIT 0" {
+		t.Errorf("unexpected wrapped statement: %q", statement)
+	}
+	if len(cols) != 2 || cols[0].Name != "ID" || cols[0].Nullable || !cols[1].Nullable {
+		t.Errorf("unexpected columns: %+v", cols)
+	}
+}
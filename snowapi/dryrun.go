@@ -0,0 +1,41 @@
+package snowapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// BuildRequest returns the request Execute would send for statement,
+// without sending it, so tests can assert on the URL, headers and JSON body
+// (read it with req.GetBody; it is gzipped past Config.GzipRequestThreshold).
+// The Authorization header carries a freshly minted token, so redact it
+// before logging the request.
+func (c *Client) BuildRequest(statement string, async bool, opts *RequestOptions) (*http.Request, error) {
+	return c.BuildRequestContext(context.Background(), statement, async, opts)
+}
+
+// BuildRequestContext is like BuildRequest but binds ctx to the request.
+func (c *Client) BuildRequestContext(ctx context.Context, statement string, async bool, opts *RequestOptions) (*http.Request, error) {
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+	req, _, err := c.newStatementRequest(ctx, body, async, opts)
+	return req, err
+}
+
+// BuildRequestWithBindings is like BuildRequest for the request
+// ExecuteWithBindings would send.
+func (c *Client) BuildRequestWithBindings(statement string, bindings []any, opts *RequestOptions) (*http.Request, error) {
+	bound, err := buildBindings(bindings)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+	body.Bindings = bound
+	req, _, err := c.newStatementRequest(context.Background(), body, false, opts)
+	return req, err
+}
@@ -0,0 +1,73 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestBuildRequest(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	})
+	client.config.Parameters = map[string]string{"QUERY_TAG": "etl"}
+
+	opts := &RequestOptions{
+		RequestID: "11111111-2222-3333-4444-555555555555",
+		Warehouse: "WH",
+	}
+	req, err := client.BuildRequest("SELECT * FROM t", true, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if req.Method != http.MethodPost {
+		t.Errorf("unexpected method %s", req.Method)
+	}
+	q := req.URL.Query()
+	if q.Get("async") != "true" || q.Get("requestId") != opts.RequestID || q.Get("retry") != "true" {
+		t.Errorf("unexpected query %s", req.URL.RawQuery)
+	}
+	if req.Header.Get("Authorization") == "" || req.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", req.Header)
+	}
+
+	rc, err := req.GetBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := io.ReadAll(rc)
+	var body QueryRequest
+	if err := json.Unmarshal(b, &body); err != nil {
+		t.Fatalf("invalid body %s: %v", b, err)
+	}
+	if body.Statement != "SELECT * FROM t" || body.Warehouse != "WH" || body.Parameters["QUERY_TAG"] != "etl" {
+		t.Errorf("unexpected body %s", b)
+	}
+}
+
+func TestBuildRequestWithBindings(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	req, err := client.BuildRequestWithBindings("SELECT * FROM t WHERE id = ?", []any{5}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.URL.Query().Get("async") != "false" {
+		t.Errorf("unexpected query %s", req.URL.RawQuery)
+	}
+	var body QueryRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if b := body.Bindings["1"]; b.Type != "FIXED" || b.Value == nil || *b.Value != "5" {
+		t.Errorf("unexpected bindings %+v", body.Bindings)
+	}
+}
+
+func TestBuildRequest_InvalidOptions(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	if _, err := client.BuildRequest("SELECT 1", false, &RequestOptions{ResultFormat: "xml"}); err == nil {
+		t.Error("expected an error for an unsupported result format")
+	}
+}
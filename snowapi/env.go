@@ -0,0 +1,87 @@
+package snowapi
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+// ConfigFromEnv builds a Config from SNOWFLAKE_* environment variables:
+//
+//	SNOWFLAKE_ACCOUNT, SNOWFLAKE_USER            required
+//	SNOWFLAKE_PRIVATE_KEY                        inline PEM, or
+//	SNOWFLAKE_PRIVATE_KEY_PATH                   path to a PEM file (one is required)
+//	SNOWFLAKE_PRIVATE_KEY_PASSPHRASE             optional, for encrypted keys
+//	SNOWFLAKE_PUBLIC_KEY, SNOWFLAKE_PUBLIC_KEY_PATH
+//	                                             optional; derived from the private key
+//	SNOWFLAKE_ROLE, SNOWFLAKE_WAREHOUSE,
+//	SNOWFLAKE_DATABASE, SNOWFLAKE_SCHEMA         optional session context
+//
+// Inline PEM values may use literal "\n" sequences in place of newlines.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Account:   os.Getenv("SNOWFLAKE_ACCOUNT"),
+		User:      os.Getenv("SNOWFLAKE_USER"),
+		Role:      os.Getenv("SNOWFLAKE_ROLE"),
+		Warehouse: os.Getenv("SNOWFLAKE_WAREHOUSE"),
+		Database:  os.Getenv("SNOWFLAKE_DATABASE"),
+		Schema:    os.Getenv("SNOWFLAKE_SCHEMA"),
+	}
+	if p := os.Getenv("SNOWFLAKE_PRIVATE_KEY_PASSPHRASE"); p != "" {
+		cfg.Passphrase = []byte(p)
+	}
+
+	var missing []string
+	if cfg.Account == "" {
+		missing = append(missing, "SNOWFLAKE_ACCOUNT")
+	}
+	if cfg.User == "" {
+		missing = append(missing, "SNOWFLAKE_USER")
+	}
+
+	priv, err := envPEM("SNOWFLAKE_PRIVATE_KEY", "SNOWFLAKE_PRIVATE_KEY_PATH")
+	if err != nil {
+		return Config{}, err
+	}
+	if priv == nil {
+		missing = append(missing, "SNOWFLAKE_PRIVATE_KEY or SNOWFLAKE_PRIVATE_KEY_PATH")
+	}
+	if len(missing) > 0 {
+		return Config{}, fmt.Errorf("missing required environment variables: %s", strings.Join(missing, ", "))
+	}
+	cfg.PrivateKey = priv
+
+	pub, err := envPEM("SNOWFLAKE_PUBLIC_KEY", "SNOWFLAKE_PUBLIC_KEY_PATH")
+	if err != nil {
+		return Config{}, err
+	}
+	if pub == nil {
+		if pub, err = auth.PublicKeyPEM(priv, cfg.Passphrase); err != nil {
+			return Config{}, fmt.Errorf("failed to derive public key: %w", err)
+		}
+	}
+	cfg.PublicKey = pub
+
+	return cfg, nil
+}
+
+// envPEM reads PEM material inline from inlineVar or from the file named by
+// pathVar, returning nil when neither is set.
+func envPEM(inlineVar, pathVar string) ([]byte, error) {
+	if v := os.Getenv(inlineVar); v != "" {
+		if !strings.Contains(v, "\n") {
+			v = strings.ReplaceAll(v, `\n`, "\n")
+		}
+		return []byte(v), nil
+	}
+	if path := os.Getenv(pathVar); path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", pathVar, err)
+		}
+		return b, nil
+	}
+	return nil, nil
+}
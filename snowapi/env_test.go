@@ -0,0 +1,73 @@
+package snowapi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	t.Setenv("SNOWFLAKE_ACCOUNT", "xy12345")
+	t.Setenv("SNOWFLAKE_USER", "loader")
+	t.Setenv("SNOWFLAKE_WAREHOUSE", "WH")
+	t.Setenv("SNOWFLAKE_PRIVATE_KEY", strings.ReplaceAll(string(priv), "\n", `\n`))
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Account != "xy12345" || cfg.User != "loader" || cfg.Warehouse != "WH" {
+		t.Errorf("unexpected config: %v", cfg)
+	}
+	if string(cfg.PrivateKey) != string(priv) {
+		t.Error("inline private key was not unescaped")
+	}
+	derived, err := auth.PublicKeyPEM(priv, nil)
+	if err != nil || string(cfg.PublicKey) != string(derived) || string(derived) != string(pub) {
+		t.Errorf("expected public key derived from the private key (err %v)", err)
+	}
+	if _, err := NewClient(cfg); err != nil {
+		t.Errorf("config from env rejected: %v", err)
+	}
+}
+
+func TestConfigFromEnv_KeyPath(t *testing.T) {
+	priv, _ := testKeyPair(t)
+	path := filepath.Join(t.TempDir(), "rsa_key.p8")
+	if err := os.WriteFile(path, priv, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SNOWFLAKE_ACCOUNT", "xy12345")
+	t.Setenv("SNOWFLAKE_USER", "loader")
+	t.Setenv("SNOWFLAKE_PRIVATE_KEY", "")
+	t.Setenv("SNOWFLAKE_PRIVATE_KEY_PATH", path)
+
+	cfg, err := ConfigFromEnv()
+	if err != nil || string(cfg.PrivateKey) != string(priv) {
+		t.Errorf("expected key read from file (err %v)", err)
+	}
+}
+
+func TestConfigFromEnv_Missing(t *testing.T) {
+	t.Setenv("SNOWFLAKE_ACCOUNT", "xy12345")
+	t.Setenv("SNOWFLAKE_USER", "")
+	t.Setenv("SNOWFLAKE_PRIVATE_KEY", "")
+	t.Setenv("SNOWFLAKE_PRIVATE_KEY_PATH", "")
+
+	_, err := ConfigFromEnv()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, name := range []string{"SNOWFLAKE_USER", "SNOWFLAKE_PRIVATE_KEY_PATH"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("expected %s in error: %v", name, err)
+		}
+	}
+	if strings.Contains(err.Error(), "SNOWFLAKE_ACCOUNT") {
+		t.Errorf("did not expect SNOWFLAKE_ACCOUNT in error: %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package snowapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrMaxRetriesExceeded is returned when WaitUntilComplete runs out of polls
+// before the statement finishes.
+var ErrMaxRetriesExceeded = errors.New("max retries exceeded while waiting for completion")
+
+// AuthError reports a failure to produce credentials for a request, or
+// Snowflake rejecting them (HTTPStatus 401 or 403) even after a token
+// refresh.
+type AuthError struct {
+	Err        error
+	HTTPStatus int // non-zero when the server rejected the token
+}
+
+func (e *AuthError) Error() string {
+	if e.HTTPStatus != 0 {
+		return fmt.Sprintf("authentication rejected: %v", e.Err)
+	}
+	return fmt.Sprintf("failed to generate auth token: %v", e.Err)
+}
+
+func (e *AuthError) Unwrap() error { return e.Err }
+
+// APIError is a non-success response from the Snowflake SQL API. Use
+// errors.As to inspect its fields, e.g. to branch on SQLState.IsSyntaxError.
+type APIError struct {
+	Code            string
+	Message         string
+	SQLState        SQLState
+	StatementHandle string
+	HTTPStatus      int
+
+	op string // prefix for Error(), e.g. "cancel failed"
+}
+
+func (e *APIError) Error() string {
+	op := e.op
+	if op == "" {
+		op = "API error"
+	}
+	if e.Code == "" {
+		return fmt.Sprintf("%s: %s", op, e.Message)
+	}
+	return fmt.Sprintf("%s: %s (code %s)", op, e.Message, e.Code)
+}
+
+// newAPIError builds an APIError from a decoded response.
+func newAPIError(op string, status int, resp *QueryResponse) *APIError {
+	e := &APIError{HTTPStatus: status, op: op}
+	if resp != nil {
+		e.Code = resp.Code
+		e.Message = resp.Message
+		e.SQLState = SQLState(resp.SQLState)
+		e.StatementHandle = resp.StatementHandle
+	}
+	return e
+}
+
+// TimeoutError reports a statement that exceeded its timeout on the server
+// (HTTP 408). The statement is no longer running and may be resubmitted,
+// for example with a larger RequestOptions.StatementTimeout. It unwraps to
+// the underlying *APIError.
+type TimeoutError struct {
+	APIError
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("statement %s timed out: %s", e.StatementHandle, e.Message)
+	}
+	return fmt.Sprintf("statement %s timed out: %s (code %s)", e.StatementHandle, e.Message, e.Code)
+}
+
+func (e *TimeoutError) Unwrap() error { return &e.APIError }
+
+// statusError builds the error for a non-success status, distinguishing
+// statement timeouts and privilege failures from other API errors.
+func statusError(op string, status int, resp *QueryResponse) error {
+	if status == http.StatusRequestTimeout {
+		return &TimeoutError{APIError: *newAPIError(op, status, resp)}
+	}
+	if resp != nil && resp.SQLState == sqlStateInsufficientPrivileges {
+		return newPrivilegeError(newAPIError(op, status, resp))
+	}
+	return newAPIError(op, status, resp)
+}
@@ -0,0 +1,101 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestExecute_ReturnsAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"002003","message":"Object does not exist","sqlState":"42S02","statementHandle":"h-1"}`))
+	})
+
+	_, err := client.Execute("SELECT * FROM missing", false, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.SQLState != "42S02" || apiErr.HTTPStatus != http.StatusUnprocessableEntity || apiErr.StatementHandle != "h-1" {
+		t.Errorf("unexpected error fields: %+v", apiErr)
+	}
+	if err.Error() != "API error: Object does not exist (code 002003)" {
+		t.Errorf("unexpected message: %s", err)
+	}
+}
+
+func TestWaitUntilComplete_ReturnsTypedErrors(t *testing.T) {
+	status := http.StatusAccepted
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"code":"100038","message":"Numeric value is not recognized","sqlState":"22018"}`))
+	})
+
+	_, err := client.WaitUntilComplete("test-handle", time.Millisecond, 2)
+	if !errors.Is(err, ErrMaxRetriesExceeded) {
+		t.Errorf("expected ErrMaxRetriesExceeded, got: %v", err)
+	}
+
+	status = http.StatusUnprocessableEntity
+	_, err = client.WaitUntilComplete("test-handle", time.Millisecond, 2)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.SQLState != "22018" {
+		t.Fatalf("expected *APIError with SQLState, got: %v", err)
+	}
+	if err.Error() != "query execution failed: Numeric value is not recognized (code 100038)" {
+		t.Errorf("unexpected message: %s", err)
+	}
+}
+
+func TestCancel_ReturnsAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"000709","message":"Statement not found"}`))
+	})
+
+	err := client.Cancel("h-2")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatementHandle != "h-2" || apiErr.HTTPStatus != http.StatusNotFound {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestExecute_ReturnsAuthError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request should not be sent without a token")
+	})
+	client.auth = &KeyPairAuthenticator{PrivateKey: []byte("not a key")}
+
+	_, err := client.Execute("SELECT 1", false, nil)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected *AuthError, got %T: %v", err, err)
+	}
+}
+
+func TestStatementTimeout_ReturnsTimeoutError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusRequestTimeout)
+		w.Write([]byte(`{"code":"000630","message":"Statement reached its statement or warehouse timeout","sqlState":"57014"}`))
+	})
+
+	_, err := client.Execute("SELECT SYSTEM$WAIT(600)", false, nil)
+	var timeoutErr *TimeoutError
+	if !errors.As(err, &timeoutErr) || timeoutErr.Code != "000630" {
+		t.Fatalf("expected *TimeoutError, got %T: %v", err, err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusRequestTimeout {
+		t.Errorf("expected TimeoutError to unwrap to *APIError, got: %v", err)
+	}
+
+	_, err = client.WaitUntilComplete("h-3", time.Millisecond, 2)
+	if !errors.As(err, &timeoutErr) || timeoutErr.StatementHandle != "h-3" {
+		t.Fatalf("expected *TimeoutError for h-3, got: %v", err)
+	}
+	if err.Error() != "statement h-3 timed out: Statement reached its statement or warehouse timeout (code 000630)" {
+		t.Errorf("unexpected message: %s", err)
+	}
+}
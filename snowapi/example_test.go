@@ -0,0 +1,96 @@
+package snowapi_test
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+func ExampleClient_QueryStream() {
+	privKey, _ := os.ReadFile("rsa_key.p8")
+	pubKey, _ := os.ReadFile("rsa_key.pub")
+
+	client, err := snowapi.NewClient(snowapi.Config{
+		Account:     "your-account-id",
+		User:        "your-username",
+		PrivateKey:  privKey,
+		PublicKey:   pubKey,
+		ExpireAfter: time.Minute,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Only one partition is held in memory at a time, so this works for
+	// results with millions of rows.
+	it, err := client.QueryStream("SELECT id, name FROM big_table")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer it.Close()
+
+	var count int
+	for it.Next() {
+		var (
+			id   int64
+			name string
+		)
+		if err := it.Scan(&id, &name); err != nil {
+			log.Fatal(err)
+		}
+		count++
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("rows:", count)
+}
+
+func ExampleClient_GetResults() {
+	privKey, _ := os.ReadFile("rsa_key.p8")
+
+	client, err := snowapi.NewClient(snowapi.Config{
+		Account:    "your-account-id",
+		User:       "your-username",
+		PrivateKey: privKey,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// First process: start a long-running statement and persist its handle.
+	h, err := client.ExecuteAsync("SELECT * FROM big_table", nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("statement.handle", []byte(h.Handle), 0o600); err != nil {
+		log.Fatal(err)
+	}
+
+	// Second process (e.g. after a restart): fetch the results by handle.
+	handle, err := os.ReadFile("statement.handle")
+	if err != nil {
+		log.Fatal(err)
+	}
+	resp, err := client.GetResults(string(handle))
+	if errors.Is(err, snowapi.ErrStatementRunning) {
+		fmt.Println("not finished yet; try again later")
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	n, err := client.PartitionCount(resp.StatementHandle)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rows, err := client.FetchPartitionRange(resp.StatementHandle, 0, n)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("rows:", len(rows))
+}
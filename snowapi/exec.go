@@ -0,0 +1,18 @@
+package snowapi
+
+import "context"
+
+// Exec runs a DML or DDL statement and returns the number of affected rows
+// (see QueryResponse.RowsAffected). DDL returns 0.
+func (c *Client) Exec(statement string) (int64, error) {
+	return c.ExecContext(context.Background(), statement)
+}
+
+// ExecContext is like Exec but honors ctx.
+func (c *Client) ExecContext(ctx context.Context, statement string) (int64, error) {
+	resp, err := c.queryCompleted(ctx, statement)
+	if err != nil {
+		return 0, err
+	}
+	return resp.RowsAffected()
+}
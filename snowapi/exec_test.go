@@ -0,0 +1,40 @@
+package snowapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestExec(t *testing.T) {
+	body := ""
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want int64
+	}{
+		{"insert", `{"resultSetMetaData":{"rowType":[{"name":"number of rows inserted","type":"fixed","scale":0}]},"data":[["3"]]}`, 3},
+		{"merge", `{"resultSetMetaData":{"rowType":[{"name":"number of rows inserted","type":"fixed"},{"name":"number of rows updated","type":"fixed"}]},"data":[["2","5"]]}`, 7},
+		{"update", `{"resultSetMetaData":{"rowType":[{"name":"number of rows updated","type":"fixed"},{"name":"number of multi-joined rows updated","type":"fixed"}]},"data":[["4","0"]]}`, 4},
+		{"ddl", `{"resultSetMetaData":{"rowType":[{"name":"status","type":"text"}]},"data":[["Table T successfully created."]]}`, 0},
+	}
+	for _, tt := range tests {
+		body = tt.body
+		n, err := client.Exec("statement")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		if n != tt.want {
+			t.Errorf("%s: expected %d, got %d", tt.name, tt.want, n)
+		}
+	}
+
+	body = `{"resultSetMetaData":{"rowType":[{"name":"number of rows deleted","type":"fixed"}]},"data":[["many"]]}`
+	if _, err := client.Exec("DELETE FROM t"); err == nil {
+		t.Error("expected error for unparseable count")
+	}
+}
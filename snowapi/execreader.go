@@ -0,0 +1,139 @@
+package snowapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// ExecuteReader is like Execute with async false, but reads the statement
+// from r, for very large generated statements. The statement is escaped
+// straight into the JSON request body, so it is never held as a string
+// alongside a marshaled copy. The body is still buffered in full (it must
+// be replayable for retries and token refreshes, and Content-Length is
+// required), and results of these statements are not cached.
+func (c *Client) ExecuteReader(r io.Reader, opts *RequestOptions) (*QueryResponse, error) {
+	return c.ExecuteReaderContext(context.Background(), r, opts)
+}
+
+// ExecuteReaderContext is like ExecuteReader but honors ctx.
+func (c *Client) ExecuteReaderContext(ctx context.Context, r io.Reader, opts *RequestOptions) (*QueryResponse, error) {
+	body, err := c.newQueryRequest("", opts)
+	if err != nil {
+		return nil, err
+	}
+	if body.encoded, err = encodeStatementRequest(body, r); err != nil {
+		return nil, err
+	}
+	return c.submit(ctx, body, false, opts)
+}
+
+// encodeStatementRequest marshals body with its statement read from r.
+// body.Statement must be empty: it marshals first as `{"statement":""`,
+// and the escaped contents of r are spliced in between the quotes.
+func encodeStatementRequest(body QueryRequest, r io.Reader) ([]byte, error) {
+	rest, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	const prefix = `{"statement":"`
+	if !bytes.HasPrefix(rest, []byte(prefix+`"`)) {
+		return nil, fmt.Errorf("unexpected request encoding %q", bodySnippet(rest))
+	}
+	rest = rest[len(prefix):]
+
+	var buf bytes.Buffer
+	if l, ok := r.(interface{ Len() int }); ok {
+		buf.Grow(len(prefix) + l.Len() + len(rest))
+	}
+	buf.WriteString(prefix)
+	if err := writeJSONString(&buf, r); err != nil {
+		return nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+	buf.Write(rest)
+	return buf.Bytes(), nil
+}
+
+// writeJSONString writes the contents of r to buf as the inside of a JSON
+// string, replacing invalid UTF-8 with U+FFFD as encoding/json does.
+func writeJSONString(buf *bytes.Buffer, r io.Reader) error {
+	chunk := make([]byte, 32<<10)
+	carry := 0 // bytes of an incomplete rune kept from the previous read
+	for {
+		n, err := r.Read(chunk[carry:])
+		n += carry
+		end := n
+		if err == nil {
+			// Hold back a trailing partial rune until the next read.
+			for i := n - 1; i >= 0 && i >= n-utf8.UTFMax; i-- {
+				if utf8.RuneStart(chunk[i]) {
+					if !utf8.FullRune(chunk[i:n]) {
+						end = i
+					}
+					break
+				}
+			}
+		}
+		escapeJSON(buf, chunk[:end])
+		carry = copy(chunk, chunk[end:n])
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// escapeJSON writes p to buf with the escaping encoding/json applies inside
+// strings, except that <, > and & are left as is.
+func escapeJSON(buf *bytes.Buffer, p []byte) {
+	const hexDigits = "0123456789abcdef"
+	start := 0
+	for i := 0; i < len(p); {
+		if c := p[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			buf.Write(p[start:i])
+			switch c {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(c)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hexDigits[c>>4])
+				buf.WriteByte(hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+		c, size := utf8.DecodeRune(p[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			buf.Write(p[start:i])
+			buf.WriteString(`\ufffd`)
+		case c == '\u2028' || c == '\u2029':
+			buf.Write(p[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hexDigits[c&0xf])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	buf.Write(p[start:])
+}
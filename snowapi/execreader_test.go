@@ -0,0 +1,130 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestEncodeStatementRequest(t *testing.T) {
+	client := &Client{}
+	for _, stmt := range []string{
+		"SELECT 1",
+		"SELECT 'it''s', \"Q\"\"ID\", '\\n' FROM t\n\tWHERE x <> 1 & y",
+		"INSERT INTO t VALUES ('\x00\x1f', 'café 日本', '  ')",
+		"SELECT '\xff\xfe broken utf8'",
+		"",
+	} {
+		body, err := client.newQueryRequest("", &RequestOptions{Role: "R", Parameters: map[string]string{"QUERY_TAG": "x"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		full := body
+		full.Statement = stmt
+		want, _ := json.Marshal(full)
+		var wantBody QueryRequest
+		json.Unmarshal(want, &wantBody)
+
+		// OneByteReader splits multi-byte runes across reads.
+		for _, r := range []io.Reader{strings.NewReader(stmt), iotest.OneByteReader(strings.NewReader(stmt))} {
+			got, err := encodeStatementRequest(body, r)
+			if err != nil {
+				t.Fatalf("%q: unexpected error: %v", stmt, err)
+			}
+			var gotBody QueryRequest
+			if err := json.Unmarshal(got, &gotBody); err != nil {
+				t.Fatalf("%q: invalid JSON %s: %v", stmt, got, err)
+			}
+			if !reflect.DeepEqual(gotBody, wantBody) {
+				t.Errorf("%q: got %s, want %s", stmt, got, want)
+			}
+		}
+	}
+}
+
+func TestExecuteReader(t *testing.T) {
+	stmt := "INSERT INTO t VALUES " + strings.Repeat("(1, 'a\"b'),", 1000) + "(2, 'c')"
+	var got QueryRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("invalid request body: %v", err)
+		}
+		w.Write([]byte(`{"code":"090001","statementHandle":"h1","data":[["1001"]]}`))
+	})
+	client.config.ResultCache = NewResultCache(0, 0)
+
+	// A reader without Len exercises the unsized path.
+	resp, err := client.ExecuteReader(io.MultiReader(strings.NewReader(stmt)), &RequestOptions{Warehouse: "WH"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Statement != stmt || got.Warehouse != "WH" || resp.StatementHandle != "h1" {
+		t.Errorf("unexpected request %.80q (warehouse %q)", got.Statement, got.Warehouse)
+	}
+	if n := client.config.ResultCache.Len(); n != 0 {
+		t.Errorf("expected no cache entries, got %d", n)
+	}
+}
+
+// statementSource generates a large statement the way a bulk DML generator
+// would, without materializing it.
+type statementSource struct {
+	rows int
+	pos  int
+	head bool
+}
+
+const (
+	sourceHead = "INSERT INTO t (id, name) VALUES "
+	sourceRow  = "(12345, 'some \"quoted\" name'),\n"
+)
+
+func (s *statementSource) Read(p []byte) (int, error) {
+	if !s.head {
+		s.head = true
+		return copy(p, sourceHead), nil
+	}
+	n := 0
+	for n < len(p) && s.rows > 0 {
+		c := copy(p[n:], sourceRow[s.pos:])
+		n += c
+		if s.pos += c; s.pos == len(sourceRow) {
+			s.pos = 0
+			s.rows--
+		}
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+func BenchmarkEncodeRequest(b *testing.B) {
+	const rows = 20000
+	client := &Client{}
+	body, _ := client.newQueryRequest("", nil)
+
+	b.Run("Marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			stmt, _ := io.ReadAll(&statementSource{rows: rows})
+			full := body
+			full.Statement = string(stmt)
+			if _, err := json.Marshal(full); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Reader", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := encodeStatementRequest(body, &statementSource{rows: rows}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
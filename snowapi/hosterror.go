@@ -0,0 +1,77 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+// lookupHost resolves candidate hosts for HostError suggestions; swapped
+// out in tests.
+var lookupHost = net.DefaultResolver.LookupHost
+
+// candidateLookupTimeout bounds each Config.RegionCandidates lookup.
+const candidateLookupTimeout = 2 * time.Second
+
+// HostError is returned when the Snowflake host does not resolve, most often
+// because the account identifier is missing its region or organization.
+// Suggestion says what to change, when the client can tell.
+type HostError struct {
+	Host       string
+	Suggestion string
+	Err        error
+}
+
+func (e *HostError) Error() string {
+	msg := fmt.Sprintf("cannot resolve Snowflake host %s: %v", e.Host, e.Err)
+	if e.Suggestion != "" {
+		msg += "; " + e.Suggestion
+	}
+	return msg
+}
+
+func (e *HostError) Unwrap() error { return e.Err }
+
+// explainHostError wraps a "no such host" failure for host in a *HostError;
+// other errors are returned unchanged.
+func (c *Client) explainHostError(ctx context.Context, host string, err error) error {
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		return err
+	}
+	return &HostError{Host: host, Suggestion: c.hostSuggestion(ctx), Err: err}
+}
+
+// hostSuggestion returns advice for an account host that does not resolve.
+// Only hosts derived from the account alone get region advice.
+func (c *Client) hostSuggestion(ctx context.Context) string {
+	cfg := c.config
+	switch {
+	case cfg.BaseURL != "" || cfg.OverrideHost != "":
+		return ""
+	case cfg.Region != "":
+		return fmt.Sprintf("check the account identifier %q and Region %q", cfg.Account, cfg.Region)
+	}
+
+	domain := "snowflakecomputing.com"
+	if cfg.PrivateLink {
+		domain = "privatelink." + domain
+	}
+	_, account := auth.AccountIdentifiers(cfg.Account)
+	for _, region := range cfg.RegionCandidates {
+		region = strings.Trim(region, ".")
+		candidate := account + "." + region + "." + domain
+		lookupCtx, cancel := context.WithTimeout(ctx, candidateLookupTimeout)
+		_, err := lookupHost(lookupCtx, candidate)
+		cancel()
+		if err == nil {
+			return fmt.Sprintf("%s resolves; set Config.Region to %q", candidate, region)
+		}
+	}
+	return `if the account is outside the default region, set Config.Region (e.g. "east-us-2.azure") or use the "<orgname>-<account_name>" account identifier`
+}
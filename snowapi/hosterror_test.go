@@ -0,0 +1,102 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newDNSFailureClient returns a client whose every request fails to resolve
+// its host.
+func newDNSFailureClient(t *testing.T, cfg Config) *Client {
+	t.Helper()
+	priv, pub := testKeyPair(t)
+	cfg.User, cfg.PrivateKey, cfg.PublicKey = "TESTUSER", priv, pub
+	cfg.HTTPClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: r.URL.Hostname(), IsNotFound: true}
+	})}
+	client, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestHostError_DefaultHost(t *testing.T) {
+	client := newDNSFailureClient(t, Config{Account: "myacct"})
+
+	_, err := client.Query("SELECT 1")
+	var hostErr *HostError
+	if !errors.As(err, &hostErr) {
+		t.Fatalf("expected a *HostError, got %v", err)
+	}
+	if hostErr.Host != "myacct.snowflakecomputing.com" {
+		t.Errorf("unexpected host %q", hostErr.Host)
+	}
+	for _, want := range []string{"cannot resolve Snowflake host myacct.snowflakecomputing.com", "Config.Region", "<orgname>-<account_name>"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in error: %v", want, err)
+		}
+	}
+	var dnsErr *net.DNSError
+	if !errors.As(err, &dnsErr) {
+		t.Error("expected the DNS error to stay reachable")
+	}
+}
+
+func TestHostError_RegionCandidates(t *testing.T) {
+	var looked []string
+	orig := lookupHost
+	lookupHost = func(ctx context.Context, host string) ([]string, error) {
+		looked = append(looked, host)
+		if host == "myacct.east-us-2.azure.snowflakecomputing.com" {
+			return []string{"192.0.2.1"}, nil
+		}
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+	defer func() { lookupHost = orig }()
+
+	client := newDNSFailureClient(t, Config{
+		Account:          "myacct",
+		RegionCandidates: []string{"us-east-2.aws", "east-us-2.azure", "eu-west-1"},
+	})
+
+	_, err := client.Query("SELECT 1")
+	want := `myacct.east-us-2.azure.snowflakecomputing.com resolves; set Config.Region to "east-us-2.azure"`
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("expected %q in error: %v", want, err)
+	}
+	if len(looked) != 2 {
+		t.Errorf("expected lookups to stop at the first match, got %v", looked)
+	}
+}
+
+func TestHostError_ExplicitHosts(t *testing.T) {
+	client := newDNSFailureClient(t, Config{Account: "myacct", Region: "us-east-2.aws"})
+	_, err := client.Query("SELECT 1")
+	if err == nil || !strings.Contains(err.Error(), `check the account identifier "myacct" and Region "us-east-2.aws"`) {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	client = newDNSFailureClient(t, Config{Account: "myacct", BaseURL: "https://gateway.invalid"})
+	_, err = client.Query("SELECT 1")
+	var hostErr *HostError
+	if !errors.As(err, &hostErr) || hostErr.Suggestion != "" {
+		t.Errorf("expected a *HostError without a suggestion for BaseURL, got %v", err)
+	}
+}
+
+func TestHostError_OtherNetworkErrors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	refused := errors.New("connection refused")
+	if got := client.explainHostError(context.Background(), "h", refused); got != refused {
+		t.Errorf("expected other errors unchanged, got %v", got)
+	}
+	timeout := &net.DNSError{Err: "i/o timeout", IsTimeout: true}
+	if got := client.explainHostError(context.Background(), "h", timeout); got != error(timeout) {
+		t.Errorf("expected DNS timeouts unchanged, got %v", got)
+	}
+}
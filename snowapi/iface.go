@@ -0,0 +1,23 @@
+package snowapi
+
+import (
+	"context"
+	"time"
+)
+
+// SnowClient is the statement API implemented by *Client. Depend on it
+// instead of *Client to inject fakes in tests; see package snowapitest.
+type SnowClient interface {
+	Query(statement string) ([][]any, error)
+	QueryContext(ctx context.Context, statement string) ([][]any, error)
+	Execute(statement string, async bool, opts *RequestOptions) (*QueryResponse, error)
+	ExecuteContext(ctx context.Context, statement string, async bool, opts *RequestOptions) (*QueryResponse, error)
+	Poll(handle string, partition int) (*QueryResponse, int, error)
+	PollContext(ctx context.Context, handle string, partition int) (*QueryResponse, int, error)
+	Cancel(statementHandle string) error
+	CancelContext(ctx context.Context, statementHandle string) error
+	WaitUntilComplete(handle string, interval time.Duration, maxRetries int) (*QueryResponse, error)
+	WaitUntilCompleteContext(ctx context.Context, handle string, interval time.Duration, maxRetries int) (*QueryResponse, error)
+}
+
+var _ SnowClient = (*Client)(nil)
@@ -0,0 +1,257 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// RowIterator streams result rows, fetching one partition at a time so at
+// most a single partition is held in memory. With Config.PrefetchPartitions
+// set, later partitions are fetched in the background while earlier ones are
+// read; Close stops the fetching.
+type RowIterator struct {
+	client *Client
+	ctx    context.Context
+	handle string
+
+	columns    []ColumnMeta
+	partitions int
+
+	rows      [][]any
+	row       int // index into rows of the current row
+	partition int // index of the partition held in rows
+
+	prefetchDepth int
+	prefetched    chan prefetchedPartition // nil until prefetching starts
+	cancel        context.CancelFunc
+
+	err    error
+	closed bool
+}
+
+// prefetchedPartition is a partition fetched ahead of iteration, or the
+// error that stopped prefetching.
+type prefetchedPartition struct {
+	partition int
+	rows      [][]any
+	err       error
+}
+
+// QueryStream executes statement and returns an iterator over its rows.
+func (c *Client) QueryStream(statement string) (*RowIterator, error) {
+	return c.QueryStreamContext(context.Background(), statement)
+}
+
+// QueryStreamContext is like QueryStream but honors ctx for the statement
+// and every partition fetched during iteration.
+func (c *Client) QueryStreamContext(ctx context.Context, statement string) (*RowIterator, error) {
+	opts := &RequestOptions{}
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.submit(ctx, body, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = c.awaitCompletion(ctx, resp, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewRowIterator(ctx, resp), nil
+}
+
+// ForEachRow executes statement and calls fn with every row, in order,
+// across all partitions. Partitions are fetched lazily as in QueryStream, so
+// at most one is held in memory. If fn returns an error, iteration stops, no
+// further partitions are fetched (beyond any already prefetched), and that
+// error is returned as is.
+func (c *Client) ForEachRow(statement string, fn func(row []any) error) error {
+	return c.ForEachRowContext(context.Background(), statement, fn)
+}
+
+// ForEachRowContext is like ForEachRow but honors ctx.
+func (c *Client) ForEachRowContext(ctx context.Context, statement string, fn func(row []any) error) error {
+	it, err := c.QueryStreamContext(ctx, statement)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		if err := fn(it.Row()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// NewRowIterator iterates over a completed response, starting with the rows
+// it already holds and fetching remaining partitions lazily using ctx.
+func (c *Client) NewRowIterator(ctx context.Context, resp *QueryResponse) *RowIterator {
+	partitions := len(resp.ResultSetMetaData.PartitionInfo)
+	if partitions == 0 {
+		partitions = 1
+	}
+	return &RowIterator{
+		client:     c,
+		ctx:        ctx,
+		handle:     resp.StatementHandle,
+		columns:    resp.ResultSetMetaData.RowType,
+		partitions: partitions,
+		rows:       resp.Data,
+		row:        -1,
+
+		prefetchDepth: c.config.PrefetchPartitions,
+	}
+}
+
+// Columns returns the result column metadata.
+func (it *RowIterator) Columns() []ColumnMeta {
+	return it.columns
+}
+
+// Next advances to the next row, fetching the next partition when the
+// current one is exhausted. It returns false at the end or on error.
+func (it *RowIterator) Next() bool {
+	if it.closed || it.err != nil {
+		return false
+	}
+	if it.prefetched == nil && it.prefetchDepth > 0 && it.partitions > 1 {
+		it.startPrefetch()
+	}
+
+	it.row++
+	for it.row >= len(it.rows) {
+		if it.partition+1 >= it.partitions {
+			it.rows = nil
+			return false
+		}
+
+		next, err := it.nextPartition()
+		if err != nil {
+			it.err = err
+			it.rows = nil
+			return false
+		}
+		it.partition = next.partition
+		it.rows, it.row = next.rows, 0
+	}
+	return true
+}
+
+// nextPartition returns the partition after the current one, from the
+// prefetch channel when prefetching.
+func (it *RowIterator) nextPartition() (prefetchedPartition, error) {
+	if it.prefetched == nil {
+		partition := it.partition + 1
+		resp, err := it.client.fetchPartition(it.ctx, it.handle, partition)
+		if err != nil {
+			return prefetchedPartition{}, err
+		}
+		return prefetchedPartition{partition: partition, rows: resp.Data}, nil
+	}
+
+	select {
+	case next := <-it.prefetched:
+		return next, next.err
+	case <-it.ctx.Done():
+		return prefetchedPartition{}, it.ctx.Err()
+	}
+}
+
+// startPrefetch fetches partitions 1 onwards in a goroutine, buffering up
+// to prefetchDepth of them. It stops at the first error, which is delivered
+// in place of the failed partition, or when the iterator is closed.
+func (it *RowIterator) startPrefetch() {
+	ctx, cancel := context.WithCancel(it.ctx)
+	it.ctx, it.cancel = ctx, cancel
+	it.prefetched = make(chan prefetchedPartition, it.prefetchDepth)
+
+	client, handle, out := it.client, it.handle, it.prefetched
+	first, partitions := it.partition+1, it.partitions
+	go func() {
+		for p := first; p < partitions; p++ {
+			next := prefetchedPartition{partition: p}
+			resp, err := client.fetchPartition(ctx, handle, p)
+			if err != nil {
+				next.err = err
+			} else {
+				next.rows = resp.Data
+			}
+			select {
+			case out <- next:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// Row returns the raw values of the current row.
+func (it *RowIterator) Row() []any {
+	if it.row < 0 || it.row >= len(it.rows) {
+		return nil
+	}
+	return it.rows[it.row]
+}
+
+// Scan copies the current row into dest, one pointer per column, converting
+// values using the column metadata. A *any receives the raw value.
+func (it *RowIterator) Scan(dest ...any) error {
+	row := it.Row()
+	if row == nil {
+		return errors.New("scan called without a current row")
+	}
+	return scanRow(row, it.columns, dest)
+}
+
+// Err returns the error, if any, that stopped iteration.
+func (it *RowIterator) Err() error {
+	return it.err
+}
+
+// Close releases the buffered partitions and stops any prefetching; further
+// calls to Next return false.
+func (it *RowIterator) Close() error {
+	it.closed = true
+	it.rows = nil
+	if it.cancel != nil {
+		it.cancel()
+	}
+	return nil
+}
+
+// scanRow assigns each value of row into the matching dest pointer.
+func scanRow(row []any, cols []ColumnMeta, dest []any) error {
+	if len(dest) != len(row) {
+		return fmt.Errorf("expected %d destination arguments, got %d", len(row), len(dest))
+	}
+	for i, d := range dest {
+		if raw, ok := d.(*any); ok {
+			*raw = row[i]
+			continue
+		}
+
+		v := reflect.ValueOf(d)
+		if v.Kind() != reflect.Ptr || v.IsNil() {
+			return fmt.Errorf("destination %d must be a non-nil pointer, got %T", i, d)
+		}
+
+		var col ColumnMeta
+		if i < len(cols) {
+			col = cols[i]
+		}
+		if err := assignValue(v.Elem(), row[i], col); err != nil {
+			return fmt.Errorf("column %d (%s): %w", i, col.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+package snowapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRowIterator_StreamsPartitions(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1", "2"}, {"3"}, {"4", "5"}})
+	var fetched []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Query().Get("partition"))
+		serve(w, r)
+	})
+
+	it, err := client.QueryStream("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer it.Close()
+	it.columns = []ColumnMeta{{Name: "N", Type: "fixed", Scale: intPtr(0)}}
+
+	var got []int64
+	for it.Next() {
+		var n int64
+		if err := it.Scan(&n); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		got = append(got, n)
+
+		// Only the partition being read should have been fetched so far.
+		if n == 3 && len(fetched) != 2 {
+			t.Errorf("expected lazy fetch, %d requests made by row 3", len(fetched))
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("unexpected rows: %v", got)
+	}
+}
+
+func TestRowIterator_PartitionError(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1"}, {"2"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		serve(w, r)
+	})
+
+	it, err := client.QueryStream("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rows := 0
+	for it.Next() {
+		rows++
+	}
+	if rows != 1 || it.Err() == nil || !strings.Contains(it.Err().Error(), "partition 1") {
+		t.Errorf("expected failure after 1 row, got %d rows, err %v", rows, it.Err())
+	}
+}
+
+func TestRowIterator_Prefetch(t *testing.T) {
+	parts := [][]string{{"1", "2"}, {"3"}, {"4", "5"}, {"6"}}
+	serve := partitionHandler(t, parts)
+	requested := make(chan string, len(parts))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if p := r.URL.Query().Get("partition"); p != "" {
+			requested <- p
+		}
+		serve(w, r)
+	})
+	client.config.PrefetchPartitions = 2
+
+	it, err := client.QueryStream("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer it.Close()
+
+	var got []string
+	for it.Next() {
+		got = append(got, it.Row()[0].(string))
+		if len(got) == 1 {
+			// Partition 1 is fetched while partition 0 is still being read.
+			select {
+			case p := <-requested:
+				if p != "1" {
+					t.Errorf("expected partition 1 to be prefetched first, got %s", p)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("partition 1 was not prefetched")
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "1,2,3,4,5,6" {
+		t.Errorf("unexpected rows: %v", got)
+	}
+}
+
+func TestRowIterator_PrefetchError(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1"}, {"2"}, {"3"}, {"4"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		serve(w, r)
+	})
+	client.config.PrefetchPartitions = 3
+
+	it, err := client.QueryStream("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer it.Close()
+
+	rows := 0
+	for it.Next() {
+		rows++
+	}
+	if rows != 2 || it.Err() == nil || !strings.Contains(it.Err().Error(), "partition 2") {
+		t.Errorf("expected failure after 2 rows, got %d rows, err %v", rows, it.Err())
+	}
+}
+
+func TestRowIterator_CloseStopsPrefetch(t *testing.T) {
+	parts := make([][]string, 20)
+	for i := range parts {
+		parts[i] = []string{strconv.Itoa(i)}
+	}
+	serve := partitionHandler(t, parts)
+	var fetched atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") != "" {
+			fetched.Add(1)
+		}
+		serve(w, r)
+	})
+	client.config.PrefetchPartitions = 1
+
+	it, err := client.QueryStream("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !it.Next() {
+		t.Fatalf("expected a row, err %v", it.Err())
+	}
+	it.Close()
+	time.Sleep(50 * time.Millisecond)
+	// One partition buffered plus at most one in flight.
+	if n := fetched.Load(); n > 3 {
+		t.Errorf("expected prefetching to stop at Close, %d partitions fetched", n)
+	}
+	if it.Next() {
+		t.Error("expected Next to return false after Close")
+	}
+}
+
+func TestForEachRow(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"1", "2"}, {"3"}, {"4", "5"}}))
+
+	var got []string
+	err := client.ForEachRow("SELECT n FROM t", func(row []any) error {
+		got = append(got, row[0].(string))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(got, ",") != "1,2,3,4,5" {
+		t.Errorf("unexpected rows: %v", got)
+	}
+}
+
+func TestForEachRow_StopsEarly(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1", "2"}, {"3"}, {"4", "5"}})
+	var fetched []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fetched = append(fetched, r.URL.Query().Get("partition"))
+		serve(w, r)
+	})
+
+	stop := errors.New("stop")
+	rows := 0
+	err := client.ForEachRow("SELECT n FROM t", func(row []any) error {
+		rows++
+		if row[0] == "3" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Fatalf("expected the callback's error, got %v", err)
+	}
+	if rows != 3 {
+		t.Errorf("expected 3 rows visited, got %d", rows)
+	}
+	if len(fetched) != 2 {
+		t.Errorf("expected no fetch after the callback failed, got requests %q", fetched)
+	}
+}
+
+func TestForEachRow_PartitionError(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1"}, {"2"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		serve(w, r)
+	})
+
+	err := client.ForEachRow("SELECT n FROM t", func(row []any) error { return nil })
+	if err == nil || !strings.Contains(err.Error(), "partition 1") {
+		t.Errorf("expected a partition error, got %v", err)
+	}
+}
+
+// BenchmarkRowIterator compares serial and prefetched iteration over a
+// result whose partitions each take a round trip to fetch and time to
+// process.
+func BenchmarkRowIterator(b *testing.B) {
+	const latency = 2 * time.Millisecond
+	parts := make([][]string, 10)
+	for i := range parts {
+		parts[i] = []string{strconv.Itoa(i)}
+	}
+
+	for _, depth := range []int{0, 1, 4} {
+		b.Run(fmt.Sprintf("prefetch=%d", depth), func(b *testing.B) {
+			priv, pub := testKeyPair(b)
+			srv := httptest.NewServer(delayedPartitionHandler(b, parts, latency))
+			defer srv.Close()
+			client, err := NewClient(Config{Account: "A", User: "U", PrivateKey: priv, PublicKey: pub, BaseURL: srv.URL, PrefetchPartitions: depth})
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				it, err := client.QueryStream("SELECT n FROM t")
+				if err != nil {
+					b.Fatal(err)
+				}
+				for it.Next() {
+					time.Sleep(latency) // per-row work
+				}
+				if err := it.Err(); err != nil {
+					b.Fatal(err)
+				}
+				it.Close()
+			}
+		})
+	}
+}
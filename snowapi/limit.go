@@ -0,0 +1,41 @@
+package snowapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// QueryLimit returns at most the first n rows of a query, for previews. The
+// statement is run wrapped as
+//
+//	SELECT * FROM (<statement>) LIMIT n
+//
+// so Snowflake stops producing rows early and few partitions come back; any
+// LIMIT or trailing semicolon in statement stays inside the subquery. Like
+// Describe, this only works for statements valid as a subquery (SELECT,
+// WITH ..., VALUES).
+func (c *Client) QueryLimit(statement string, n int) ([][]any, error) {
+	return c.QueryLimitContext(context.Background(), statement, n)
+}
+
+// QueryLimitContext is like QueryLimit but honors ctx.
+func (c *Client) QueryLimitContext(ctx context.Context, statement string, n int) ([][]any, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", n)
+	}
+	resp, err := c.queryCompleted(ctx, limitStatement(statement, n))
+	if err != nil {
+		return nil, err
+	}
+	return c.collectPartitions(ctx, resp)
+}
+
+// limitStatement wraps statement in an outer query returning at most n rows.
+// The newline before the closing parenthesis keeps a trailing -- comment
+// from swallowing it.
+func limitStatement(statement string, n int) string {
+	inner := strings.TrimRight(strings.TrimSpace(statement), "; \t\n")
+	return "SELECT * FROM (\n" + inner + "\n) LIMIT " + strconv.Itoa(n)
+}
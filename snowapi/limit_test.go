@@ -0,0 +1,59 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestLimitStatement(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		want      string
+	}{
+		{"plain", "SELECT * FROM events", "SELECT * FROM (\nSELECT * FROM events\n) LIMIT 10"},
+		{"trailing semicolons", "  SELECT 1;;\n ", "SELECT * FROM (\nSELECT 1\n) LIMIT 10"},
+		{"existing limit", "SELECT * FROM events ORDER BY ts LIMIT 500", "SELECT * FROM (\nSELECT * FROM events ORDER BY ts LIMIT 500\n) LIMIT 10"},
+		{"trailing comment", "SELECT 1 -- preview", "SELECT * FROM (\nSELECT 1 -- preview\n) LIMIT 10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitStatement(tt.statement, 10); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLimit(t *testing.T) {
+	var statement string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body QueryRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		statement = body.Statement
+		w.Write([]byte(`{"code":"090001","resultSetMetaData":{"rowType":[{"name":"ID","type":"fixed"}]},"data":[["1"],["2"]]}`))
+	})
+
+	rows, err := client.QueryLimit("SELECT id FROM users;", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statement != "SELECT * FROM (\nSELECT id FROM users\n) LIMIT 2" {
+		t.Errorf("unexpected wrapped statement: %q", statement)
+	}
+	if len(rows) != 2 || rows[1][0] != "2" {
+		t.Errorf("unexpected rows: %v", rows)
+	}
+}
+
+func TestQueryLimit_InvalidLimit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+	for _, n := range []int{0, -1} {
+		if _, err := client.QueryLimit("SELECT 1", n); err == nil {
+			t.Errorf("expected an error for limit %d", n)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package snowapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Logger receives request/response tracing from the client. Bearer tokens are
+// never passed to it.
+type Logger interface {
+	Debugf(format string, args ...any)
+	Infof(format string, args ...any)
+	Errorf(format string, args ...any)
+}
+
+// nopLogger discards everything; it is used when Config.Logger is nil.
+type nopLogger struct{}
+
+func (nopLogger) Debugf(string, ...any) {}
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Errorf(string, ...any) {}
+
+// redactURL strips credentials and secret-looking query values from u.
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	clean := *u
+	clean.User = nil
+
+	q := clean.Query()
+	for key := range q {
+		lower := strings.ToLower(key)
+		if strings.Contains(lower, "token") || strings.Contains(lower, "secret") || strings.Contains(lower, "password") {
+			q.Set(key, "REDACTED")
+		}
+	}
+	clean.RawQuery = q.Encode()
+	return clean.String()
+}
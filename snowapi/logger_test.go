@@ -0,0 +1,58 @@
+package snowapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recordingLogger captures formatted log lines for assertions.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) record(level, format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, level+" "+fmt.Sprintf(format, args...))
+}
+
+func (l *recordingLogger) Debugf(format string, args ...any) { l.record("DEBUG", format, args...) }
+func (l *recordingLogger) Infof(format string, args ...any)  { l.record("INFO", format, args...) }
+func (l *recordingLogger) Errorf(format string, args ...any) { l.record("ERROR", format, args...) }
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
+func TestLogger_TracesLifecycleWithoutToken(t *testing.T) {
+	var token string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		token = strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		w.Write([]byte(`{"code":"090001","statementHandle":"handle-123"}`))
+	})
+	logger := &recordingLogger{}
+	client.logger = logger
+
+	if _, err := client.Execute("SELECT 1", false, &RequestOptions{RequestID: "req-42"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := client.Poll("handle-123", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := logger.String()
+	for _, want := range []string{"POST", "GET", "requestId=req-42", "handle=handle-123", "-> 200"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected log output to contain %q:\n%s", want, out)
+		}
+	}
+	if token == "" || strings.Contains(out, token) {
+		t.Errorf("bearer token leaked into logs")
+	}
+}
@@ -0,0 +1,23 @@
+package snowapi
+
+import "time"
+
+// Metrics receives operational measurements from the client. Operations are
+// tagged "execute", "poll" and "cancel".
+type Metrics interface {
+	// ObserveRequestDuration records the total time of an operation,
+	// including any retries.
+	ObserveRequestDuration(op string, d time.Duration)
+	// IncRetry counts a retried attempt.
+	IncRetry(op string)
+	// IncError counts a failed operation; code is the HTTP status, or
+	// "network" when no response was received.
+	IncError(op string, code string)
+}
+
+// nopMetrics discards everything; it is used when Config.Metrics is nil.
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequestDuration(string, time.Duration) {}
+func (nopMetrics) IncRetry(string)                              {}
+func (nopMetrics) IncError(string, string)                      {}
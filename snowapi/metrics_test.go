@@ -0,0 +1,76 @@
+package snowapi
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// recordingMetrics counts observations per operation.
+type recordingMetrics struct {
+	mu        sync.Mutex
+	durations map[string]int
+	retries   map[string]int
+	errors    map[string][]string
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{durations: map[string]int{}, retries: map[string]int{}, errors: map[string][]string{}}
+}
+
+func (m *recordingMetrics) ObserveRequestDuration(op string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.durations[op]++
+}
+
+func (m *recordingMetrics) IncRetry(op string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries[op]++
+}
+
+func (m *recordingMetrics) IncError(op, code string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.errors[op] = append(m.errors[op], code)
+}
+
+func TestMetrics_RecordsOperations(t *testing.T) {
+	var polls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code":"001003","message":"syntax error"}`))
+			return
+		}
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	metrics := newRecordingMetrics()
+	client.metrics = metrics
+	client.config.Retry = RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	client.Execute("SELEC 1", false, nil)
+	if _, _, err := client.Poll("test-handle", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if metrics.durations["execute"] != 1 || metrics.durations["poll"] != 1 {
+		t.Errorf("unexpected durations: %v", metrics.durations)
+	}
+	if metrics.retries["poll"] != 1 {
+		t.Errorf("expected 1 poll retry, got %v", metrics.retries)
+	}
+	if len(metrics.errors["execute"]) != 1 || metrics.errors["execute"][0] != "422" {
+		t.Errorf("unexpected errors: %v", metrics.errors)
+	}
+	if len(metrics.errors["poll"]) != 0 {
+		t.Errorf("retried poll should not count as an error: %v", metrics.errors["poll"])
+	}
+}
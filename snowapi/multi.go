@@ -0,0 +1,67 @@
+package snowapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ExecuteMulti submits statements in a single request using Snowflake's
+// MULTI_STATEMENT_COUNT parameter and returns each sub-statement's result in
+// order.
+func (c *Client) ExecuteMulti(statements []string, opts *RequestOptions) ([]*QueryResponse, error) {
+	return c.ExecuteMultiContext(context.Background(), statements, opts)
+}
+
+// ExecuteMultiContext is like ExecuteMulti but honors ctx.
+func (c *Client) ExecuteMultiContext(ctx context.Context, statements []string, opts *RequestOptions) ([]*QueryResponse, error) {
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("at least one statement is required")
+	}
+
+	parts := make([]string, len(statements))
+	for i, stmt := range statements {
+		parts[i] = strings.TrimRight(strings.TrimSpace(stmt), ";")
+	}
+
+	body, err := c.newQueryRequest(strings.Join(parts, ";\n"), opts)
+	if err != nil {
+		return nil, err
+	}
+	if body.Parameters == nil {
+		body.Parameters = map[string]string{}
+	}
+	body.Parameters["MULTI_STATEMENT_COUNT"] = strconv.Itoa(len(statements))
+
+	resp, err := c.submit(ctx, body, false, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.StatementHandles) == 0 {
+		resp, err = c.awaitCompletion(ctx, resp, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(resp.StatementHandles) != len(statements) {
+		return nil, fmt.Errorf("expected %d statement handles, got %d", len(statements), len(resp.StatementHandles))
+	}
+
+	results := make([]*QueryResponse, len(resp.StatementHandles))
+	for i, handle := range resp.StatementHandles {
+		sub, status, err := c.PollContext(ctx, handle, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch result of statement %d: %w", i+1, err)
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch result of statement %d: %w",
+				i+1, newAPIError(fmt.Sprintf("unexpected status %d", status), status, sub))
+		}
+		results[i] = sub
+	}
+	return results, nil
+}
@@ -0,0 +1,36 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestExecuteMulti(t *testing.T) {
+	var body QueryRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"code":"090001","statementHandle":"parent","statementHandles":["h1","h2"]}`))
+		case strings.HasSuffix(r.URL.Path, "/h1"):
+			w.Write([]byte(`{"statementHandle":"h1","data":[["1"]]}`))
+		case strings.HasSuffix(r.URL.Path, "/h2"):
+			w.Write([]byte(`{"statementHandle":"h2","data":[["2"]]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+		}
+	})
+
+	results, err := client.ExecuteMulti([]string{"SELECT 1;", "SELECT 2"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Statement != "SELECT 1;\nSELECT 2" || body.Parameters["MULTI_STATEMENT_COUNT"] != "2" {
+		t.Errorf("unexpected request body: %+v", body)
+	}
+	if len(results) != 2 || results[0].Data[0][0] != "1" || results[1].Data[0][0] != "2" {
+		t.Errorf("unexpected results: %+v", results)
+	}
+}
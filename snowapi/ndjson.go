@@ -0,0 +1,104 @@
+package snowapi
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// StreamNDJSON executes statement and writes its rows to w as
+// newline-delimited JSON, one object per row keyed by column name in column
+// order, with values decoded as in ToRecords (Decimal as a JSON number,
+// []byte as base64). Partitions are fetched lazily and output is flushed
+// after each one, including an http.Flusher w. If a partition fetch fails,
+// the rows already written stay written and the error is returned.
+func (c *Client) StreamNDJSON(statement string, w io.Writer) error {
+	return c.StreamNDJSONContext(context.Background(), statement, w)
+}
+
+// StreamNDJSONContext is like StreamNDJSON but honors ctx.
+func (c *Client) StreamNDJSONContext(ctx context.Context, statement string, w io.Writer) error {
+	it, err := c.QueryStreamContext(ctx, statement)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	bw := bufio.NewWriter(w)
+	flush := func() error {
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	keys := ndjsonKeys(it.Columns())
+	partition := it.partition
+	for n := 0; it.Next(); n++ {
+		if it.partition != partition {
+			partition = it.partition
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+		if err := writeNDJSONRow(bw, keys, it.Columns(), it.Row()); err != nil {
+			flush()
+			return fmt.Errorf("row %d: %w", n, err)
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	return it.Err()
+}
+
+// ndjsonKeys returns the encoded object key, with its colon, per column.
+func ndjsonKeys(cols []ColumnMeta) [][]byte {
+	names := make([]string, len(cols))
+	for i, col := range cols {
+		names[i] = col.Name
+	}
+	names = uniqueColumnNames(names)
+	keys := make([][]byte, len(names))
+	for i, name := range names {
+		k, _ := json.Marshal(name)
+		keys[i] = append(k, ':')
+	}
+	return keys
+}
+
+func writeNDJSONRow(w *bufio.Writer, keys [][]byte, cols []ColumnMeta, row []any) error {
+	w.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		w.Write(key)
+
+		var raw any
+		if i < len(row) {
+			raw = row[i]
+		}
+		v, err := DecodeValue(raw, cols[i])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", cols[i].Name, err)
+		}
+		if d, ok := v.(Decimal); ok {
+			w.WriteString(d.String())
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", cols[i].Name, err)
+		}
+		w.Write(b)
+	}
+	w.WriteString("}\n")
+	return nil
+}
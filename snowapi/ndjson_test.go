@@ -0,0 +1,74 @@
+package snowapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const ndjsonMeta = `"resultSetMetaData":{"rowType":[` +
+	`{"name":"ID","type":"fixed","scale":0},{"name":"NAME","type":"text"},` +
+	`{"name":"ATTRS","type":"object"},{"name":"ID","type":"fixed","scale":0}],` +
+	`"partitionInfo":[{"rowCount":2},{"rowCount":1},{"rowCount":1}]}`
+
+// flushRecorder records what had been written at each Flush.
+type flushRecorder struct {
+	strings.Builder
+	flushes []string
+}
+
+func (f *flushRecorder) Flush() { f.flushes = append(f.flushes, f.String()) }
+
+func TestStreamNDJSON(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("partition") {
+		case "":
+			w.Write([]byte(`{"code":"090001","statementHandle":"h1",` + ndjsonMeta +
+				`,"data":[["1","a","{\"k\":[1,2]}","10"],["2",null,null,null]]}`))
+		case "1":
+			w.Write([]byte(`{"data":[["3","c","{}","30"]]}`))
+		case "2":
+			w.Write([]byte(`{"data":[["4","d","[]","40"]]}`))
+		}
+	})
+
+	var out flushRecorder
+	if err := client.StreamNDJSON("SELECT * FROM t", &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `{"ID":1,"NAME":"a","ATTRS":{"k":[1,2]},"ID_2":10}
+{"ID":2,"NAME":null,"ATTRS":null,"ID_2":null}
+{"ID":3,"NAME":"c","ATTRS":{},"ID_2":30}
+{"ID":4,"NAME":"d","ATTRS":[],"ID_2":40}
+`
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+	if len(out.flushes) != 3 || strings.Count(out.flushes[0], "\n") != 2 {
+		t.Errorf("expected a flush per partition, got %q", out.flushes)
+	}
+}
+
+func TestStreamNDJSON_PartitionError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("partition") {
+		case "":
+			w.Write([]byte(`{"code":"090001","statementHandle":"h1",` + ndjsonMeta +
+				`,"data":[["1","a","{}","10"],["2","b","{}","20"]]}`))
+		case "1":
+			w.Write([]byte(`{"data":[["3","c","{}","30"]]}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"code":"000000","message":"boom"}`))
+		}
+	})
+
+	var out strings.Builder
+	err := client.StreamNDJSON("SELECT * FROM t", &out)
+	if err == nil || !strings.Contains(err.Error(), "partition 2") {
+		t.Fatalf("expected the partition 2 error, got %v", err)
+	}
+	if n := strings.Count(out.String(), "\n"); n != 3 {
+		t.Errorf("expected the 3 rows before the failure to be written, got %d:\n%s", n, out.String())
+	}
+}
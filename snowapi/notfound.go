@@ -0,0 +1,97 @@
+package snowapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults for the not-found grace retry; see Config.NotFoundRetries.
+const (
+	defaultNotFoundRetries    = 3
+	defaultNotFoundRetryDelay = 500 * time.Millisecond
+
+	// notFoundGraceWindow is how long after submission a not-found poll is
+	// treated as the statement not being registered yet.
+	notFoundGraceWindow = 5 * time.Second
+
+	// statementNotFoundCode is Snowflake's error code for an unknown handle.
+	statementNotFoundCode = "000709"
+)
+
+// isStatementNotFound reports whether a poll response means Snowflake does
+// not know the handle, as opposed to the statement having failed.
+func isStatementNotFound(status int, resp *QueryResponse) bool {
+	if resp != nil && resp.Code == statementNotFoundCode {
+		return true
+	}
+	return status == http.StatusNotFound && (resp == nil || resp.Code == "")
+}
+
+// pollWithGrace polls handle, retrying a not-found response for a statement
+// this client submitted within the last notFoundGraceWindow, since Snowflake
+// can briefly report a fresh handle as unknown.
+func (c *Client) pollWithGrace(ctx context.Context, handle string, partition int) (*QueryResponse, int, error) {
+	retries := c.config.NotFoundRetries
+	if retries == 0 {
+		retries = defaultNotFoundRetries
+	}
+	delay := c.config.NotFoundRetryDelay
+	if delay <= 0 {
+		delay = defaultNotFoundRetryDelay
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, status, err := c.poll(ctx, handle, partition)
+		if err != nil || attempt >= retries || !isStatementNotFound(status, resp) || !c.submitted.recent(handle) {
+			return resp, status, err
+		}
+		c.logger.Infof("snowapi: statement %s not found yet, retrying in %v", handle, delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, 0, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// maxTrackedSubmissions bounds how many handles submissionLog remembers.
+const maxTrackedSubmissions = 1024
+
+// submissionLog remembers when recent statement handles were submitted.
+type submissionLog struct {
+	mu    sync.Mutex
+	times map[string]time.Time
+	order []string
+}
+
+func (l *submissionLog) record(handle string, at time.Time) {
+	if handle == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.times == nil {
+		l.times = make(map[string]time.Time)
+	}
+	if _, ok := l.times[handle]; !ok {
+		if len(l.order) >= maxTrackedSubmissions {
+			delete(l.times, l.order[0])
+			l.order = l.order[1:]
+		}
+		l.order = append(l.order, handle)
+	}
+	l.times[handle] = at
+}
+
+// recent reports whether handle was submitted within notFoundGraceWindow.
+func (l *submissionLog) recent(handle string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	at, ok := l.times[handle]
+	return ok && time.Since(at) < notFoundGraceWindow
+}
@@ -0,0 +1,103 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilComplete_GraceRetryOnNotFound(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		polls++
+		switch polls {
+		case 1:
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"code":"000709","message":"Statement h1 not found"}`))
+		case 2:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+		default:
+			w.Write([]byte(`{"code":"090001","statementHandle":"h1","data":[["1"]]}`))
+		}
+	})
+	client.config.NotFoundRetryDelay = time.Millisecond
+	client.config.PollStrategy = PollStrategy{Initial: time.Millisecond, Max: time.Millisecond}
+
+	rows, err := client.ExecuteAndWait("SELECT 1", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 1 || polls != 3 {
+		t.Errorf("expected 1 row after 3 polls, got %d rows, %d polls", len(rows), polls)
+	}
+}
+
+func TestPoll_NoGraceForUnknownOrOldHandles(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"000709","message":"Statement not found"}`))
+	})
+	client.config.NotFoundRetryDelay = time.Millisecond
+
+	// Never submitted by this client.
+	if _, status, err := client.Poll("h1", 0); err != nil || status != http.StatusUnprocessableEntity || polls != 1 {
+		t.Errorf("expected a single poll, got status %d, %d polls (err %v)", status, polls, err)
+	}
+
+	// Submitted too long ago.
+	polls = 0
+	client.submitted.record("h2", time.Now().Add(-time.Minute))
+	_, err := client.WaitUntilComplete("h2", time.Millisecond, 5)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || polls != 1 {
+		t.Errorf("expected immediate failure, got %v after %d polls", err, polls)
+	}
+
+	// Recent, but retries exhausted.
+	polls = 0
+	client.submitted.record("h3", time.Now())
+	if _, _, err := client.Poll("h3", 0); err != nil || polls != 1+defaultNotFoundRetries {
+		t.Errorf("expected %d polls, got %d (err %v)", 1+defaultNotFoundRetries, polls, err)
+	}
+
+	// Disabled.
+	polls = 0
+	client.config.NotFoundRetries = -1
+	if _, _, err := client.Poll("h3", 0); err != nil || polls != 1 {
+		t.Errorf("expected no retry when disabled, got %d polls (err %v)", polls, err)
+	}
+}
+
+func TestPoll_GraceRetryHonorsCancellation(t *testing.T) {
+	var polls atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls.Add(1)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"000709","message":"Statement h1 not found"}`))
+	})
+	client.config.NotFoundRetryDelay = time.Minute
+	client.submitted.record("h1", time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for polls.Load() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		cancel()
+	}()
+	resp, status, err := client.PollContext(ctx, "h1", 0)
+	if !errors.Is(err, context.Canceled) || resp != nil || status != 0 {
+		t.Errorf("expected context.Canceled, got resp %v, status %d, err %v", resp, status, err)
+	}
+}
@@ -0,0 +1,168 @@
+package snowapi
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Option configures a client created by NewClientWithOptions.
+type Option func(*Config)
+
+// NewClientWithOptions initializes a client for account and user, applying
+// opts on top of the defaults used by NewClient.
+func NewClientWithOptions(account, user string, opts ...Option) (*Client, error) {
+	cfg := Config{Account: account, User: user}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return NewClient(cfg)
+}
+
+// WithRole sets the role used for statements.
+func WithRole(role string) Option {
+	return func(c *Config) { c.Role = role }
+}
+
+// WithWarehouse sets the warehouse used for statements.
+func WithWarehouse(warehouse string) Option {
+	return func(c *Config) { c.Warehouse = warehouse }
+}
+
+// WithDatabase sets the database used for statements.
+func WithDatabase(database string) Option {
+	return func(c *Config) { c.Database = database }
+}
+
+// WithSchema sets the schema used for statements.
+func WithSchema(schema string) Option {
+	return func(c *Config) { c.Schema = schema }
+}
+
+// WithPrivateKey sets the PEM-encoded key pair used to sign JWTs.
+func WithPrivateKey(privateKey, publicKey []byte) Option {
+	return func(c *Config) {
+		c.PrivateKey = privateKey
+		c.PublicKey = publicKey
+	}
+}
+
+// WithPassphrase sets the passphrase for an encrypted private key.
+func WithPassphrase(passphrase []byte) Option {
+	return func(c *Config) { c.Passphrase = passphrase }
+}
+
+// WithAuthenticator replaces key-pair authentication.
+func WithAuthenticator(a Authenticator) Option {
+	return func(c *Config) { c.Authenticator = a }
+}
+
+// WithHTTPTimeout sets the transport-level timeout of the default HTTP client.
+func WithHTTPTimeout(d time.Duration) Option {
+	return func(c *Config) { c.HTTPTimeout = d }
+}
+
+// WithHTTPClient supplies the HTTP client used for all requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Config) { c.HTTPClient = hc }
+}
+
+// WithExpireAfter sets the lifetime of generated JWTs.
+func WithExpireAfter(d time.Duration) Option {
+	return func(c *Config) { c.ExpireAfter = d }
+}
+
+// WithRetryConfig sets the retry policy for transient HTTP failures.
+func WithRetryConfig(rc RetryConfig) Option {
+	return func(c *Config) { c.Retry = rc }
+}
+
+// WithLogger sets the request/response tracing logger.
+func WithLogger(l Logger) Option {
+	return func(c *Config) { c.Logger = l }
+}
+
+// WithMetrics sets the operational metrics hooks.
+func WithMetrics(m Metrics) Option {
+	return func(c *Config) { c.Metrics = m }
+}
+
+// WithResultCache enables the in-memory result cache.
+func WithResultCache(rc *ResultCache) Option {
+	return func(c *Config) { c.ResultCache = rc }
+}
+
+// WithUserAgent prepends ua to the default User-Agent.
+func WithUserAgent(ua string) Option {
+	return func(c *Config) { c.UserAgent = ua }
+}
+
+// WithHeader adds a header sent with every request; see Config.Headers.
+func WithHeader(name, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(map[string]string)
+		}
+		c.Headers[name] = value
+	}
+}
+
+// RequestOption configures RequestOptions built by NewRequestOptions.
+type RequestOption func(*RequestOptions)
+
+// NewRequestOptions returns RequestOptions with opts applied. Unless
+// WithRequestID supplies one, a random UUID request ID is generated, so
+// Snowflake can deduplicate resubmissions and the client retries transient
+// failures (see RequestOptions.DedupRetry).
+func NewRequestOptions(opts ...RequestOption) *RequestOptions {
+	o := &RequestOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.RequestID == "" {
+		o.RequestID = uuid.New().String()
+	}
+	return o
+}
+
+// WithRequestID sets the request ID instead of generating one.
+func WithRequestID(id string) RequestOption {
+	return func(o *RequestOptions) { o.RequestID = id }
+}
+
+// WithTimeout sets the statement timeout, rounded up to whole seconds.
+func WithTimeout(d time.Duration) RequestOption {
+	return func(o *RequestOptions) {
+		o.StatementTimeout = int((d + time.Second - 1) / time.Second)
+	}
+}
+
+// WithParameters adds session parameters for the statement; repeated uses
+// merge, later values winning.
+func WithParameters(params map[string]string) RequestOption {
+	return func(o *RequestOptions) {
+		if o.Parameters == nil {
+			o.Parameters = make(map[string]string, len(params))
+		}
+		for k, v := range params {
+			o.Parameters[k] = v
+		}
+	}
+}
+
+// WithStatementRole sets the role for the statement, overriding
+// Config.Role. (WithRole sets the client-wide role.)
+func WithStatementRole(role string) RequestOption {
+	return func(o *RequestOptions) { o.Role = role }
+}
+
+// WithNullable sets RequestOptions.Nullable.
+func WithNullable(nullable bool) RequestOption {
+	return func(o *RequestOptions) { o.Nullable = &nullable }
+}
+
+// WithRetry sets RequestOptions.DedupRetry.
+func WithRetry(retry bool) RequestOption {
+	return func(o *RequestOptions) { o.DedupRetry = &retry }
+}
@@ -0,0 +1,79 @@
+package snowapi
+
+import (
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRequestOptions_Defaults(t *testing.T) {
+	a, b := NewRequestOptions(), NewRequestOptions()
+	if _, err := uuid.Parse(a.RequestID); err != nil {
+		t.Errorf("expected a UUID request ID, got %q", a.RequestID)
+	}
+	if a.RequestID == b.RequestID {
+		t.Error("expected a fresh request ID per call")
+	}
+	if retry, err := a.dedupRetry(); err != nil || !retry {
+		t.Errorf("expected deduplicated retries by default, got %v, %v", retry, err)
+	}
+}
+
+func TestNewRequestOptions_Options(t *testing.T) {
+	got := NewRequestOptions(
+		WithRequestID("req-1"),
+		WithTimeout(1500*time.Millisecond),
+		WithParameters(map[string]string{"QUERY_TAG": "a", "TIMEZONE": "UTC"}),
+		WithParameters(map[string]string{"QUERY_TAG": "b"}),
+		WithStatementRole("ANALYST"),
+		WithNullable(false),
+		WithRetry(false),
+	)
+	f := false
+	want := &RequestOptions{
+		RequestID:        "req-1",
+		StatementTimeout: 2,
+		Parameters:       map[string]string{"QUERY_TAG": "b", "TIMEZONE": "UTC"},
+		Role:             "ANALYST",
+		Nullable:         &f,
+		DedupRetry:       &f,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestExecute_NewRequestOptionsID(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		ids []string
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.URL.Query().Get("requestId"))
+		attempt := len(ids)
+		mu.Unlock()
+		if attempt == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	client.config.Retry = RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+
+	opts := NewRequestOptions()
+	resp, err := client.Execute("SELECT 1", false, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != opts.RequestID || ids[1] != opts.RequestID {
+		t.Errorf("expected both attempts to send %s, got %v", opts.RequestID, ids)
+	}
+	if resp.RequestID != opts.RequestID {
+		t.Errorf("expected the response to carry %s, got %s", opts.RequestID, resp.RequestID)
+	}
+}
@@ -0,0 +1,95 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// errTrackingDisabled is returned by CancelAll without Config.TrackStatements.
+var errTrackingDisabled = errors.New("snowapi: CancelAll requires Config.TrackStatements")
+
+// statementSet is the set of statement handles still running, kept when
+// Config.TrackStatements is set.
+type statementSet struct {
+	mu      sync.Mutex
+	handles map[string]struct{}
+}
+
+func (s *statementSet) add(handle string) {
+	if handle == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.handles == nil {
+		s.handles = make(map[string]struct{})
+	}
+	s.handles[handle] = struct{}{}
+}
+
+func (s *statementSet) remove(handle string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.handles, handle)
+}
+
+// list returns the tracked handles in sorted order.
+func (s *statementSet) list() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	handles := make([]string, 0, len(s.handles))
+	for h := range s.handles {
+		handles = append(handles, h)
+	}
+	sort.Strings(handles)
+	return handles
+}
+
+// Outstanding returns the handles of statements this client submitted that
+// have not been seen to finish, in sorted order. It is empty unless
+// Config.TrackStatements is set.
+func (c *Client) Outstanding() []string {
+	return c.outstanding.list()
+}
+
+// CancelAll cancels every outstanding statement (see Outstanding)
+// concurrently, for use at shutdown before Close. It returns the joined
+// errors of the cancels that failed, each naming its handle, or an error if
+// Config.TrackStatements is not set.
+func (c *Client) CancelAll(ctx context.Context) error {
+	if !c.config.TrackStatements {
+		return errTrackingDisabled
+	}
+
+	handles := c.outstanding.list()
+	errs := make([]error, len(handles))
+	var wg sync.WaitGroup
+	for i, handle := range handles {
+		wg.Add(1)
+		go func(i int, handle string) {
+			defer wg.Done()
+			if err := c.CancelContext(ctx, handle); err != nil {
+				errs[i] = fmt.Errorf("cancel %s: %w", handle, err)
+			}
+		}(i, handle)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// trackStatement records handle as outstanding when tracking is enabled.
+func (c *Client) trackStatement(handle string) {
+	if c.config.TrackStatements {
+		c.outstanding.add(handle)
+	}
+}
+
+// untrackStatement forgets handle once it has finished or been canceled.
+func (c *Client) untrackStatement(handle string) {
+	if c.config.TrackStatements {
+		c.outstanding.remove(handle)
+	}
+}
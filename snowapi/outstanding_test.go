@@ -0,0 +1,87 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCancelAll(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		canceled []string
+		n        atomic.Int32
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/cancel"):
+			handle := path.Base(path.Dir(r.URL.Path))
+			mu.Lock()
+			canceled = append(canceled, handle)
+			mu.Unlock()
+			if handle == "h3" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				w.Write([]byte(`{"code":"000605","message":"already finished"}`))
+				return
+			}
+			w.Write([]byte(`{"code":"000604","message":"canceled"}`))
+		case r.Method == http.MethodGet:
+			w.Write([]byte(`{"code":"090001","data":[["1"]]}`))
+		default:
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprintf(w, `{"code":"333334","statementHandle":"h%d"}`, n.Add(1))
+		}
+	})
+	client.config.TrackStatements = true
+
+	for i := 0; i < 4; i++ {
+		if _, err := client.ExecuteAsync("CALL long_running()", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// A statement seen to finish is no longer outstanding.
+	if _, _, err := client.Poll("h4", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.Outstanding(); !reflect.DeepEqual(got, []string{"h1", "h2", "h3"}) {
+		t.Fatalf("unexpected outstanding handles: %v", got)
+	}
+
+	err := client.CancelAll(context.Background())
+	var apiErr *APIError
+	if err == nil || !strings.Contains(err.Error(), "cancel h3") || !errors.As(err, &apiErr) {
+		t.Errorf("expected the h3 failure to be reported, got %v", err)
+	}
+	mu.Lock()
+	got := append([]string(nil), canceled...)
+	mu.Unlock()
+	if len(got) != 3 {
+		t.Errorf("expected a cancel for each outstanding statement, got %v", got)
+	}
+	if got := client.Outstanding(); !reflect.DeepEqual(got, []string{"h3"}) {
+		t.Errorf("expected only the failed cancel to stay outstanding, got %v", got)
+	}
+}
+
+func TestCancelAll_TrackingDisabled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+	})
+	if _, err := client.ExecuteAsync("SELECT 1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := client.Outstanding(); len(got) != 0 {
+		t.Errorf("expected no tracking by default, got %v", got)
+	}
+	if err := client.CancelAll(context.Background()); !errors.Is(err, errTrackingDisabled) {
+		t.Errorf("expected errTrackingDisabled, got %v", err)
+	}
+}
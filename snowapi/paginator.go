@@ -0,0 +1,142 @@
+package snowapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Paginator serves fixed-size pages of a completed statement's result by
+// fetching only the partitions that hold each page, so paging does not
+// re-run the query. The most recently fetched partition is kept, so reading
+// pages in order fetches each partition once. It is safe for concurrent use.
+type Paginator struct {
+	client *Client
+	handle string
+
+	partitions []PartitionMeta
+	offsets    []int // offsets[i] is the global index of partition i's first row; the last entry is the row total
+
+	mu        sync.Mutex
+	partition int // index of the partition held in rows, or -1
+	rows      [][]any
+}
+
+// NewPaginator returns a Paginator over the result of handle. The partition
+// row counts are remembered from earlier responses for the handle when
+// possible; otherwise partition 0 is fetched to read them.
+func (c *Client) NewPaginator(handle string) (*Paginator, error) {
+	return c.NewPaginatorContext(context.Background(), handle)
+}
+
+// NewPaginatorContext is like NewPaginator but honors ctx.
+func (c *Client) NewPaginatorContext(ctx context.Context, handle string) (*Paginator, error) {
+	p := &Paginator{client: c, handle: handle, partition: -1}
+
+	partitions, ok := c.partitions.get(handle)
+	if !ok {
+		first, err := c.fetchPartition(ctx, handle, 0)
+		if err != nil {
+			return nil, err
+		}
+		partitions = first.ResultSetMetaData.PartitionInfo
+		if err := checkPartitionRows(partitions, 0, first.Data); err != nil {
+			return nil, err
+		}
+		if len(partitions) == 0 {
+			// A result without partition metadata is the single partition 0.
+			partitions = []PartitionMeta{{RowCount: len(first.Data)}}
+		}
+		p.partition, p.rows = 0, first.Data
+	}
+
+	p.partitions = partitions
+	p.offsets = make([]int, len(partitions)+1)
+	for i, meta := range partitions {
+		p.offsets[i+1] = p.offsets[i] + meta.RowCount
+	}
+	return p, nil
+}
+
+// TotalRows returns the number of rows in the result.
+func (p *Paginator) TotalRows() int {
+	return p.offsets[len(p.offsets)-1]
+}
+
+// PageCount returns how many pages of pageSize rows the result spans.
+func (p *Paginator) PageCount(pageSize int) int {
+	if pageSize <= 0 {
+		return 0
+	}
+	return (p.TotalRows() + pageSize - 1) / pageSize
+}
+
+// Page returns rows [pageNumber*pageSize, (pageNumber+1)*pageSize) of the
+// result; pageNumber 0 is the first page. The last page may be short, and
+// pages past the end are empty. A page spanning partitions fetches each of
+// them.
+func (p *Paginator) Page(pageNumber, pageSize int) ([][]any, error) {
+	return p.PageContext(context.Background(), pageNumber, pageSize)
+}
+
+// PageContext is like Page but honors ctx.
+func (p *Paginator) PageContext(ctx context.Context, pageNumber, pageSize int) ([][]any, error) {
+	if pageNumber < 0 || pageSize <= 0 {
+		return nil, fmt.Errorf("invalid page %d of size %d", pageNumber, pageSize)
+	}
+	total := p.TotalRows()
+	if total == 0 || pageNumber > (total-1)/pageSize {
+		return nil, nil
+	}
+	start := pageNumber * pageSize
+	end := total
+	if end-start > pageSize {
+		end = start + pageSize
+	}
+
+	// The first partition whose rows extend past start.
+	first := sort.Search(len(p.offsets)-1, func(i int) bool { return p.offsets[i+1] > start })
+
+	page := make([][]any, 0, end-start)
+	for i := first; i < len(p.offsets)-1 && p.offsets[i] < end; i++ {
+		rows, err := p.partitionRows(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi := start-p.offsets[i], end-p.offsets[i]
+		if lo < 0 {
+			lo = 0
+		}
+		if hi > len(rows) {
+			hi = len(rows)
+		}
+		page = append(page, rows[lo:hi]...)
+	}
+	return page, nil
+}
+
+// partitionRows returns the rows of partition i, fetching it unless it is
+// the one already held.
+func (p *Paginator) partitionRows(ctx context.Context, i int) ([][]any, error) {
+	p.mu.Lock()
+	if p.partition == i {
+		rows := p.rows
+		p.mu.Unlock()
+		return rows, nil
+	}
+	p.mu.Unlock()
+
+	resp, err := p.client.fetchPartition(ctx, p.handle, i)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPartitionRows(p.partitions, i, resp.Data); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.partition, p.rows = i, resp.Data
+	p.mu.Unlock()
+	return resp.Data, nil
+}
@@ -0,0 +1,143 @@
+package snowapi
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// pageValues joins the first column of rows.
+func pageValues(rows [][]any) string {
+	values := make([]string, len(rows))
+	for i, row := range rows {
+		values[i] = fmt.Sprint(row[0])
+	}
+	return strings.Join(values, ",")
+}
+
+func TestPaginator_UnevenPartitions(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1", "2", "3"}, {"4"}, {"5", "6", "7", "8"}, {"9", "10"}})
+	var fetched []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s: paging should not re-run the statement", r.Method)
+		}
+		partition := r.URL.Query().Get("partition")
+		if partition == "" {
+			partition = "0"
+		}
+		fetched = append(fetched, partition)
+		serve(w, r)
+	})
+
+	p, err := client.NewPaginator("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.TotalRows() != 10 || p.PageCount(3) != 4 {
+		t.Errorf("unexpected totals: %d rows, %d pages", p.TotalRows(), p.PageCount(3))
+	}
+
+	tests := []struct {
+		page    int
+		want    string
+		fetches string // partitions fetched for the page
+	}{
+		{0, "1,2,3", ""}, // partition 0 is held from NewPaginator
+		{1, "4,5,6", "1,2"},
+		{2, "7,8,9", "3"},
+		{3, "10", ""},
+		{4, "", ""},
+		{0, "1,2,3", "0"},
+	}
+	for _, tt := range tests {
+		fetched = nil
+		rows, err := p.Page(tt.page, 3)
+		if err != nil {
+			t.Fatalf("page %d: unexpected error: %v", tt.page, err)
+		}
+		if got := pageValues(rows); got != tt.want {
+			t.Errorf("page %d: expected rows %q, got %q", tt.page, tt.want, got)
+		}
+		if got := strings.Join(fetched, ","); got != tt.fetches {
+			t.Errorf("page %d: expected partitions %q fetched, got %q", tt.page, tt.fetches, got)
+		}
+	}
+}
+
+func TestPaginator_PageSpanningSeveralPartitions(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"1", "2"}, {"3"}, {"4"}, {"5", "6"}}))
+	p, err := client.NewPaginator("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, err := p.Page(0, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := pageValues(rows); got != "1,2,3,4,5" {
+		t.Errorf("unexpected rows %q", got)
+	}
+	if rows, _ := p.Page(1, 5); pageValues(rows) != "6" {
+		t.Errorf("unexpected last page %q", pageValues(rows))
+	}
+}
+
+func TestPaginator_SinglePartition(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"090001","statementHandle":"h","data":[["a"],["b"],["c"]]}`))
+	})
+	p, err := client.NewPaginator("h")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rows, err := p.Page(1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.TotalRows() != 3 || pageValues(rows) != "c" {
+		t.Errorf("unexpected page %q of %d rows", pageValues(rows), p.TotalRows())
+	}
+}
+
+func TestPaginator_InvalidPage(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"1"}}))
+	p, err := client.NewPaginator("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, args := range [][2]int{{-1, 10}, {0, 0}, {0, -5}} {
+		if _, err := p.Page(args[0], args[1]); err == nil {
+			t.Errorf("Page(%d, %d): expected an error", args[0], args[1])
+		}
+	}
+}
+
+func TestPaginator_RowCountMismatch(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"1"}, {"2", "3"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "1" {
+			w.Write([]byte(`{"code":"090001","data":[["2"]]}`))
+			return
+		}
+		serve(w, r)
+	})
+	p, err := client.NewPaginator("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := p.Page(0, 3); err == nil || !strings.Contains(err.Error(), "partition 1") {
+		t.Errorf("expected a row count error, got %v", err)
+	}
+}
+
+func TestPaginator_TruncatedFirstPartition(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"090001","statementHandle":"h","data":[["a"]],` +
+			`"resultSetMetaData":{"partitionInfo":[{"rowCount":3},{"rowCount":1}]}}`))
+	})
+	if _, err := client.NewPaginator("h"); err == nil || !strings.Contains(err.Error(), "partition 0 returned 1 rows, expected 3") {
+		t.Errorf("expected a row count error for partition 0, got %v", err)
+	}
+}
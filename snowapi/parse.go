@@ -0,0 +1,78 @@
+package snowapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// parseResult decodes the result rows of a statement or partition
+// response. format is the format that was requested; the one meta reports
+// takes precedence, and responses carrying neither (such as partition
+// fetches) are read as json. Numbers decode as json.Number so DecodeValue
+// can parse them per column without loss.
+func parseResult(format string, meta ResultSetMetaData, data json.RawMessage) ([][]any, error) {
+	if meta.Format != "" {
+		format = meta.Format
+	}
+
+	switch format {
+	case "", FormatJSON, FormatJSONv2:
+		// json holds every value as a string and jsonv2 may also hold
+		// native numbers and booleans; both are arrays of rows.
+		rows, err := decodeRows(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s result data: %w", firstNonEmpty(format, FormatJSON), err)
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("unsupported result format %q", format)
+}
+
+// decodeRows decodes a JSON array of rows, keeping numbers as json.Number.
+func decodeRows(raw json.RawMessage) ([][]any, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var data [][]any
+	if err := dec.Decode(&data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// queryEnvelope decodes a QueryResponse in a single pass, keeping the
+// result rows raw until parseResult decodes them per the result format.
+type queryEnvelope struct {
+	resp   *QueryResponse
+	format string
+}
+
+func (e *queryEnvelope) UnmarshalJSON(b []byte) error {
+	type plain QueryResponse // without methods
+	var aux struct {
+		*plain
+		Data json.RawMessage `json:"data"`
+	}
+	aux.plain = (*plain)(e.resp)
+	if err := json.Unmarshal(b, &aux); err != nil {
+		return err
+	}
+
+	data, err := parseResult(e.format, e.resp.ResultSetMetaData, aux.Data)
+	if err != nil {
+		return err
+	}
+	e.resp.Data = data
+	return nil
+}
+
+// requestedFormat returns the result format body asked for, if any.
+func requestedFormat(body QueryRequest) string {
+	if body.ResultSetMetaData == nil {
+		return ""
+	}
+	return body.ResultSetMetaData.Format
+}
@@ -0,0 +1,113 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseResult(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		raw        string
+		want       [][]any
+		wantFormat string
+	}{
+		{
+			name:       "json",
+			format:     FormatJSON,
+			raw:        `{"resultSetMetaData":{"format":"json","numRows":2},"data":[["1","a"],[null,"b"]]}`,
+			want:       [][]any{{"1", "a"}, {nil, "b"}},
+			wantFormat: FormatJSON,
+		},
+		{
+			name:       "jsonv2 native values",
+			format:     FormatJSONv2,
+			raw:        `{"resultSetMetaData":{"format":"jsonv2"},"data":[[12345678901234567890,true,1.5]]}`,
+			want:       [][]any{{json.Number("12345678901234567890"), true, json.Number("1.5")}},
+			wantFormat: FormatJSONv2,
+		},
+		{
+			name:   "partition without metadata",
+			format: "",
+			raw:    `{"data":[["2"]]}`,
+			want:   [][]any{{"2"}},
+		},
+		{
+			name:   "error response without data",
+			format: FormatJSON,
+			raw:    `{"code":"002003","message":"does not exist"}`,
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp QueryResponse
+			if err := json.Unmarshal([]byte(tt.raw), &queryEnvelope{resp: &resp, format: tt.format}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(resp.Data, tt.want) {
+				t.Errorf("data = %#v, want %#v", resp.Data, tt.want)
+			}
+			if resp.ResultSetMetaData.Format != tt.wantFormat {
+				t.Errorf("format = %q, want %q", resp.ResultSetMetaData.Format, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestParseResult_Errors(t *testing.T) {
+	if _, err := parseResult(FormatJSON, ResultSetMetaData{}, json.RawMessage(`{"not":"rows"}`)); err == nil || !strings.Contains(err.Error(), "invalid json result data") {
+		t.Errorf("expected invalid data error, got %v", err)
+	}
+	if _, err := parseResult("arrow", ResultSetMetaData{}, json.RawMessage(`[]`)); err == nil || !strings.Contains(err.Error(), `unsupported result format "arrow"`) {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
+
+func TestQueryEnvelope(t *testing.T) {
+	var resp QueryResponse
+	raw := `{"code":"090001","statementHandle":"h1","createdOn":1,"resultSetMetaData":{"numRows":1,"rowType":[{"name":"N","type":"fixed"}]},"data":[["7"]]}`
+	if err := json.Unmarshal([]byte(raw), &queryEnvelope{resp: &resp}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Code != "090001" || resp.StatementHandle != "h1" || resp.CreatedOn != 1 ||
+		resp.ResultSetMetaData.NumRows != 1 || len(resp.Columns()) != 1 || !reflect.DeepEqual(resp.Data, [][]any{{"7"}}) {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestQueryEnvelope_InvalidData(t *testing.T) {
+	var resp QueryResponse
+	err := json.Unmarshal([]byte(`{"resultSetMetaData":{"format":"json"},"data":{"not":"rows"}}`), &queryEnvelope{resp: &resp})
+	if err == nil || !strings.Contains(err.Error(), "invalid json result data") {
+		t.Errorf("expected invalid data error, got %v", err)
+	}
+}
+
+// BenchmarkQueryEnvelope measures decoding a statement response with a
+// sizeable result set.
+func BenchmarkQueryEnvelope(b *testing.B) {
+	var sb strings.Builder
+	sb.WriteString(`{"code":"090001","statementHandle":"h1","resultSetMetaData":{"format":"jsonv2","numRows":1000,` +
+		`"rowType":[{"name":"ID","type":"fixed"},{"name":"NAME","type":"text"}]},"data":[`)
+	for i := 0; i < 1000; i++ {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(`[12345,"some name value"]`)
+	}
+	sb.WriteString(`]}`)
+	body := []byte(sb.String())
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(body)))
+	for i := 0; i < b.N; i++ {
+		var resp QueryResponse
+		if err := json.Unmarshal(body, &queryEnvelope{resp: &resp}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
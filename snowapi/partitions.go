@@ -0,0 +1,353 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// FetchAllPartitions retrieves every partition of a completed statement and
+// returns their rows concatenated in partition order.
+func (c *Client) FetchAllPartitions(handle string) ([][]any, error) {
+	return c.FetchAllPartitionsContext(context.Background(), handle)
+}
+
+// FetchAllPartitionsContext is like FetchAllPartitions but honors ctx.
+func (c *Client) FetchAllPartitionsContext(ctx context.Context, handle string) ([][]any, error) {
+	first, err := c.fetchPartition(ctx, handle, 0)
+	if err != nil {
+		return nil, err
+	}
+	return c.collectPartitions(ctx, first)
+}
+
+// collectPartitions returns the rows of first (partition 0 of a completed
+// statement) followed by those of every remaining partition.
+func (c *Client) collectPartitions(ctx context.Context, first *QueryResponse) ([][]any, error) {
+	partitions := first.ResultSetMetaData.PartitionInfo
+	if err := checkPartitionRows(partitions, 0, first.Data); err != nil {
+		return nil, err
+	}
+
+	// Copy rather than append to first.Data, which may be a cached response
+	// shared with other callers.
+	total := len(first.Data)
+	if len(partitions) > 0 {
+		total = 0
+		for _, p := range partitions {
+			total += p.RowCount
+		}
+	}
+	handle := first.StatementHandle
+	data := make([][]any, 0, total)
+	data = append(data, first.Data...)
+	for i := 1; i < len(partitions); i++ {
+		resp, err := c.fetchPartition(ctx, handle, i)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPartitionRows(partitions, i, resp.Data); err != nil {
+			return nil, err
+		}
+		data = append(data, resp.Data...)
+	}
+
+	return data, nil
+}
+
+// ExecuteAndWait runs a statement to completion and returns all of its rows.
+// If the statement outlives the synchronous window it polls per
+// Config.PollStrategy (up to the statement timeout), then fetches every
+// partition of the result.
+func (c *Client) ExecuteAndWait(statement string, opts *RequestOptions) ([][]any, error) {
+	return c.ExecuteAndWaitContext(context.Background(), statement, opts)
+}
+
+// ExecuteAndWaitContext is like ExecuteAndWait but honors ctx.
+func (c *Client) ExecuteAndWaitContext(ctx context.Context, statement string, opts *RequestOptions) ([][]any, error) {
+	if opts == nil {
+		opts = &RequestOptions{}
+	}
+	if opts.RequestID == "" {
+		withID := *opts
+		withID.RequestID = uuid.New().String()
+		opts = &withID
+	}
+
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.submit(ctx, body, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err = c.awaitCompletion(ctx, resp, body)
+	if err != nil {
+		return nil, err
+	}
+	return c.collectPartitions(ctx, resp)
+}
+
+// FetchAllPartitionsConcurrent is like FetchAllPartitions but fetches up to
+// maxConcurrency partitions in parallel. Rows are still returned in partition
+// order; the first partition error cancels outstanding fetches.
+func (c *Client) FetchAllPartitionsConcurrent(handle string, maxConcurrency int) ([][]any, error) {
+	return c.FetchAllPartitionsConcurrentContext(context.Background(), handle, maxConcurrency)
+}
+
+// FetchAllPartitionsConcurrentContext is like FetchAllPartitionsConcurrent but honors ctx.
+func (c *Client) FetchAllPartitionsConcurrentContext(ctx context.Context, handle string, maxConcurrency int) ([][]any, error) {
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
+	first, err := c.fetchPartition(ctx, handle, 0)
+	if err != nil {
+		return nil, err
+	}
+	return c.collectPartitionsConcurrent(ctx, handle, first, maxConcurrency)
+}
+
+// collectPartitionsConcurrent is like collectPartitions but fetches up to
+// maxConcurrency of the remaining partitions of handle in parallel.
+func (c *Client) collectPartitionsConcurrent(ctx context.Context, handle string, first *QueryResponse, maxConcurrency int) ([][]any, error) {
+	partitions := first.ResultSetMetaData.PartitionInfo
+	if err := checkPartitionRows(partitions, 0, first.Data); err != nil {
+		return nil, err
+	}
+	if len(partitions) <= 1 {
+		return first.Data, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][][]any, len(partitions))
+	results[0] = first.Data
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		sem      = make(chan struct{}, maxConcurrency)
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for i := 1; i < len(partitions); i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			resp, err := c.fetchPartition(ctx, handle, i)
+			if err == nil {
+				err = checkPartitionRows(partitions, i, resp.Data)
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			results[i] = resp.Data
+		}(i)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	data := make([][]any, 0, rowTotal(results))
+	for _, rows := range results {
+		data = append(data, rows...)
+	}
+	return data, nil
+}
+
+func rowTotal(results [][][]any) int {
+	n := 0
+	for _, rows := range results {
+		n += len(rows)
+	}
+	return n
+}
+
+// waitAndFetchConcurrency bounds the partition fetches WaitAndFetchAll runs
+// in parallel.
+const waitAndFetchConcurrency = 4
+
+// WaitAndFetchAll polls handle per strategy until the statement completes,
+// then fetches its remaining partitions concurrently and returns every row
+// in partition order. It is the usual follow-up to an async Execute. Polling
+// continues until the statement finishes or fails (Snowflake enforces the
+// statement timeout) or ctx is done; canceling ctx also stops outstanding
+// partition fetches.
+func (c *Client) WaitAndFetchAll(ctx context.Context, handle string, strategy PollStrategy) ([][]any, error) {
+	first, err := c.waitUntilComplete(ctx, handle, strategy.withDefaults(), math.MaxInt32)
+	if err != nil {
+		return nil, err
+	}
+	return c.collectPartitionsConcurrent(ctx, handle, first, waitAndFetchConcurrency)
+}
+
+// ErrPartitionOutOfRange is returned by FetchPartitionRange for indices
+// outside the statement's partitions.
+var ErrPartitionOutOfRange = errors.New("partition out of range")
+
+// PartitionCount returns how many result partitions a completed statement
+// has. The count is remembered from earlier responses for the handle when
+// possible; otherwise partition 0 is fetched to read it.
+func (c *Client) PartitionCount(handle string) (int, error) {
+	return c.PartitionCountContext(context.Background(), handle)
+}
+
+// PartitionCountContext is like PartitionCount but honors ctx.
+func (c *Client) PartitionCountContext(ctx context.Context, handle string) (int, error) {
+	count, _, err := c.countPartitions(ctx, handle)
+	return count, err
+}
+
+// countPartitions does the work of PartitionCountContext, also returning
+// partition 0 when it had to be fetched (nil otherwise) so callers can
+// reuse it.
+func (c *Client) countPartitions(ctx context.Context, handle string) (int, *QueryResponse, error) {
+	if partitions, ok := c.partitions.get(handle); ok {
+		return partitionCount(partitions), nil, nil
+	}
+	first, err := c.fetchPartition(ctx, handle, 0)
+	if err != nil {
+		return 0, nil, err
+	}
+	return partitionCount(first.ResultSetMetaData.PartitionInfo), first, nil
+}
+
+// FetchPartitionRange retrieves partitions [start, end) of a completed
+// statement and returns their rows concatenated in partition order, so a
+// paginating caller can load one page of partitions at a time. Indices are
+// validated against PartitionCount; an invalid range wraps
+// ErrPartitionOutOfRange.
+func (c *Client) FetchPartitionRange(handle string, start, end int) ([][]any, error) {
+	return c.FetchPartitionRangeContext(context.Background(), handle, start, end)
+}
+
+// FetchPartitionRangeContext is like FetchPartitionRange but honors ctx.
+func (c *Client) FetchPartitionRangeContext(ctx context.Context, handle string, start, end int) ([][]any, error) {
+	count, first, err := c.countPartitions(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || end < start || end > count {
+		return nil, fmt.Errorf("%w: range [%d, %d) for statement %s with %d partitions",
+			ErrPartitionOutOfRange, start, end, handle, count)
+	}
+
+	partitions, _ := c.partitions.get(handle)
+	var data [][]any
+	for i := start; i < end; i++ {
+		resp := first
+		if i != 0 || resp == nil {
+			if resp, err = c.fetchPartition(ctx, handle, i); err != nil {
+				return nil, err
+			}
+		}
+		if err := checkPartitionRows(partitions, i, resp.Data); err != nil {
+			return nil, err
+		}
+		data = append(data, resp.Data...)
+	}
+	return data, nil
+}
+
+// partitionCount treats a result without partition metadata as the single
+// partition 0.
+func partitionCount(partitions []PartitionMeta) int {
+	if len(partitions) == 0 {
+		return 1
+	}
+	return len(partitions)
+}
+
+// maxTrackedPartitionHandles bounds how many handles partitionLog remembers.
+const maxTrackedPartitionHandles = 1024
+
+// partitionLog remembers partition metadata for recent statement handles;
+// Snowflake only returns it with partition 0.
+type partitionLog struct {
+	mu         sync.Mutex
+	partitions map[string][]PartitionMeta
+	order      []string
+}
+
+func (l *partitionLog) record(handle string, partitions []PartitionMeta) {
+	if handle == "" || len(partitions) == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.partitions == nil {
+		l.partitions = make(map[string][]PartitionMeta)
+	}
+	if _, ok := l.partitions[handle]; !ok {
+		if len(l.order) >= maxTrackedPartitionHandles {
+			delete(l.partitions, l.order[0])
+			l.order = l.order[1:]
+		}
+		l.order = append(l.order, handle)
+	}
+	l.partitions[handle] = partitions
+}
+
+func (l *partitionLog) get(handle string) ([]PartitionMeta, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	partitions, ok := l.partitions[handle]
+	return partitions, ok
+}
+
+// fetchPartition polls a single partition and requires a 200 response.
+func (c *Client) fetchPartition(ctx context.Context, handle string, partition int) (*QueryResponse, error) {
+	resp, status, err := c.PollContext(ctx, handle, partition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch partition %d: %w", partition, err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch partition %d: %w",
+			partition, newAPIError(fmt.Sprintf("unexpected status %d", status), status, resp))
+	}
+	if partition == 0 {
+		c.partitions.record(handle, resp.ResultSetMetaData.PartitionInfo)
+	}
+	return resp, nil
+}
+
+// checkPartitionRows verifies a partition's row count matches its metadata.
+func checkPartitionRows(partitions []PartitionMeta, partition int, data [][]any) error {
+	if partition >= len(partitions) {
+		return nil
+	}
+	if want := partitions[partition].RowCount; len(data) != want {
+		return fmt.Errorf("partition %d returned %d rows, expected %d", partition, len(data), want)
+	}
+	return nil
+}
@@ -0,0 +1,317 @@
+package snowapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// partitionHandler serves partitions, each a slice of single-column rows.
+func partitionHandler(t *testing.T, partitions [][]string) http.HandlerFunc {
+	return delayedPartitionHandler(t, partitions, 0)
+}
+
+// delayedPartitionHandler is like partitionHandler but delays every fetch of
+// a partition after the first by latency.
+func delayedPartitionHandler(tb testing.TB, partitions [][]string, latency time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		idx := 0
+		if p := r.URL.Query().Get("partition"); p != "" {
+			idx, _ = strconv.Atoi(p)
+		}
+		if idx > 0 && latency > 0 {
+			time.Sleep(latency)
+		}
+
+		resp := QueryResponse{Code: "090001", StatementHandle: "test-handle"}
+		for _, v := range partitions[idx] {
+			resp.Data = append(resp.Data, []any{v})
+		}
+		if idx == 0 {
+			for _, p := range partitions {
+				resp.ResultSetMetaData.PartitionInfo = append(resp.ResultSetMetaData.PartitionInfo, PartitionMeta{RowCount: len(p)})
+			}
+		}
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			tb.Errorf("failed to encode response: %v", err)
+		}
+	}
+}
+
+func TestFetchAllPartitions(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}}))
+
+	data, err := client.FetchAllPartitions("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, row := range data {
+		got = append(got, row[0].(string))
+	}
+	if strings.Join(got, ",") != "a,b,c,d,e" {
+		t.Errorf("unexpected rows: %v", got)
+	}
+}
+
+func TestCollectPartitions_LeavesFirstResponseIntact(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"a"}, {"b"}, {"c"}}))
+	first, err := client.fetchPartition(context.Background(), "test-handle", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Spare capacity, as a cached response's Data slice may have.
+	first.Data = append(make([][]any, 0, 8), first.Data...)
+	spare := first.Data[:cap(first.Data)]
+
+	data, err := client.collectPartitions(context.Background(), first)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 3 || len(first.Data) != 1 || spare[1] != nil {
+		t.Errorf("expected the first response's backing array untouched, got %d rows and spare %v", len(data), spare[1])
+	}
+}
+
+func TestFetchAllPartitions_PartitionFails(t *testing.T) {
+	ok := partitionHandler(t, [][]string{{"a"}, {"b"}, {"c"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "2" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		ok(w, r)
+	})
+
+	_, err := client.FetchAllPartitions("test-handle")
+	if err == nil || !strings.Contains(err.Error(), "partition 2") {
+		t.Errorf("expected partition 2 failure, got: %v", err)
+	}
+}
+
+func TestFetchAllPartitionsConcurrent_PreservesOrder(t *testing.T) {
+	parts := [][]string{{"p0"}, {"p1a", "p1b"}, {"p2"}, {"p3"}, {"p4"}}
+	serve := partitionHandler(t, parts)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		// Earlier partitions respond slower than later ones.
+		if p, _ := strconv.Atoi(r.URL.Query().Get("partition")); p > 0 {
+			time.Sleep(time.Duration(len(parts)-p) * 20 * time.Millisecond)
+		}
+		serve(w, r)
+	})
+
+	data, err := client.FetchAllPartitionsConcurrent("test-handle", 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []string
+	for _, row := range data {
+		got = append(got, row[0].(string))
+	}
+	if strings.Join(got, ",") != "p0,p1a,p1b,p2,p3,p4" {
+		t.Errorf("unexpected order: %v", got)
+	}
+}
+
+func TestFetchAllPartitionsConcurrent_Error(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"a"}, {"b"}, {"c"}, {"d"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") == "1" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"message":"boom"}`))
+			return
+		}
+		serve(w, r)
+	})
+
+	_, err := client.FetchAllPartitionsConcurrent("test-handle", 2)
+	if err == nil || !strings.Contains(err.Error(), "partition 1") {
+		t.Errorf("expected partition 1 failure, got: %v", err)
+	}
+}
+
+func TestExecuteAndWait_FallsBackToPolling(t *testing.T) {
+	partitions := partitionHandler(t, [][]string{{"a", "b"}, {"c"}})
+	var submitted, statusPolls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			submitted++
+			if r.URL.Query().Get("requestId") == "" {
+				t.Error("expected a requestId on submission")
+			}
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"test-handle"}`))
+			return
+		}
+		if r.URL.Query().Get("partition") == "" && statusPolls < 2 {
+			statusPolls++
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"test-handle"}`))
+			return
+		}
+		partitions(w, r)
+	})
+	client.config.PollStrategy = PollStrategy{Initial: time.Millisecond, Max: 2 * time.Millisecond}
+
+	data, err := client.ExecuteAndWait("SELECT SYSTEM$WAIT(120)", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if submitted != 1 || statusPolls != 2 {
+		t.Errorf("expected 1 submission and 2 pending polls, got %d and %d", submitted, statusPolls)
+	}
+	if len(data) != 3 || data[2][0] != "c" {
+		t.Errorf("expected rows from all partitions, got %v", data)
+	}
+}
+
+func TestFetchPartitionRange(t *testing.T) {
+	var polls []string
+	ok := partitionHandler(t, [][]string{{"a", "b"}, {"c"}, {"d", "e"}, {"f"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls = append(polls, r.URL.Query().Get("partition"))
+		ok(w, r)
+	})
+
+	n, err := client.PartitionCount("test-handle")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 4 {
+		t.Errorf("expected 4 partitions, got %d", n)
+	}
+
+	data, err := client.FetchPartitionRange("test-handle", 1, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, row := range data {
+		got = append(got, row[0].(string))
+	}
+	if strings.Join(got, ",") != "c,d,e" {
+		t.Errorf("unexpected rows: %v", got)
+	}
+	// Partition 0 is fetched once for the count, then remembered.
+	if strings.Join(polls, ",") != ",1,2" {
+		t.Errorf("unexpected partition requests: %v", polls)
+	}
+}
+
+func TestFetchPartitionRange_ColdHandleReusesFirstPartition(t *testing.T) {
+	var polls []string
+	ok := partitionHandler(t, [][]string{{"a", "b"}, {"c"}, {"d"}})
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls = append(polls, r.URL.Query().Get("partition"))
+		ok(w, r)
+	})
+
+	data, err := client.FetchPartitionRange("test-handle", 0, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 3 || data[0][0] != "a" || data[2][0] != "c" {
+		t.Errorf("unexpected rows: %v", data)
+	}
+	// Partition 0 is fetched once, for both the count and its rows.
+	if strings.Join(polls, ",") != ",1" {
+		t.Errorf("unexpected partition requests: %q", polls)
+	}
+}
+
+func TestFetchPartitionRange_OutOfRange(t *testing.T) {
+	client := newTestClient(t, partitionHandler(t, [][]string{{"a"}, {"b"}}))
+
+	for _, r := range [][2]int{{-1, 1}, {1, 0}, {0, 3}, {2, 3}} {
+		_, err := client.FetchPartitionRange("test-handle", r[0], r[1])
+		if !errors.Is(err, ErrPartitionOutOfRange) {
+			t.Errorf("range %v: expected ErrPartitionOutOfRange, got %v", r, err)
+		}
+	}
+}
+
+func TestWaitAndFetchAll(t *testing.T) {
+	parts := [][]string{{"p0"}, {"p1a", "p1b"}, {"p2"}, {"p3"}, {"p4"}, {"p5"}}
+	serve := partitionHandler(t, parts)
+	var pending int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		p, _ := strconv.Atoi(r.URL.Query().Get("partition"))
+		if p == 0 && atomic.AddInt32(&pending, 1) <= 3 {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"test-handle"}`))
+			return
+		}
+		// Earlier partitions respond slower than later ones.
+		if p > 0 {
+			time.Sleep(time.Duration(len(parts)-p) * 10 * time.Millisecond)
+		}
+		serve(w, r)
+	})
+
+	data, err := client.WaitAndFetchAll(context.Background(), "test-handle", PollStrategy{Initial: time.Millisecond, Max: 2 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := atomic.LoadInt32(&pending); n != 4 {
+		t.Errorf("expected 3 pending polls and 1 final poll, got %d", n)
+	}
+
+	var got []string
+	for _, row := range data {
+		got = append(got, row[0].(string))
+	}
+	if strings.Join(got, ",") != "p0,p1a,p1b,p2,p3,p4,p5" {
+		t.Errorf("unexpected order: %v", got)
+	}
+}
+
+func TestWaitAndFetchAll_CanceledWhilePolling(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"code":"333334","statementHandle":"test-handle"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := client.WaitAndFetchAll(ctx, "test-handle", PollStrategy{Initial: 5 * time.Millisecond, Max: 5 * time.Millisecond})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestWaitAndFetchAll_CanceledWhileFetching(t *testing.T) {
+	serve := partitionHandler(t, [][]string{{"a"}, {"b"}, {"c"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("partition") != "" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		serve(w, r)
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.WaitAndFetchAll(ctx, "test-handle", PollStrategy{})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("WaitAndFetchAll did not stop after cancellation")
+	}
+}
@@ -0,0 +1,68 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+)
+
+// Ping failure classes; match them with errors.Is on the error from Ping.
+var (
+	ErrAuthFailed     = errors.New("authentication failed")
+	ErrUnreachable    = errors.New("endpoint unreachable")
+	ErrInvalidAccount = errors.New("account URL not found")
+)
+
+// Ping verifies credentials and connectivity by running SELECT 1. Failures
+// wrap ErrAuthFailed (key or JWT rejected), ErrInvalidAccount (the account
+// host does not resolve or the endpoint is missing) or ErrUnreachable (any
+// other network failure), alongside the underlying error. Ping does not
+// retry, so it fails fast in readiness probes.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.ExecuteContext(ctx, "SELECT 1", false, &RequestOptions{StatementTimeout: 30})
+	if err == nil {
+		return nil
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("ping failed: %w", err)
+	}
+	if kind := classifyPingError(err); kind != nil {
+		return fmt.Errorf("ping failed: %w: %w", kind, err)
+	}
+	return fmt.Errorf("ping failed: %w", err)
+}
+
+// classifyPingError maps err to one of the Ping failure classes, or nil.
+func classifyPingError(err error) error {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return ErrAuthFailed
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.HTTPStatus {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrAuthFailed
+		case http.StatusNotFound:
+			return ErrInvalidAccount
+		}
+		return nil
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return ErrInvalidAccount
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ErrUnreachable
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ErrUnreachable
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	status := http.StatusOK
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		switch status {
+		case http.StatusOK:
+			w.Write([]byte(`{"statementHandle":"h1","data":[["1"]]}`))
+		case http.StatusUnauthorized:
+			w.Write([]byte(`{"code":"390144","message":"JWT token is invalid."}`))
+		default:
+			w.Write([]byte(`<html>not found</html>`))
+		}
+	})
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	status = http.StatusUnauthorized
+	err := client.Ping(context.Background())
+	var apiErr *APIError
+	if !errors.Is(err, ErrAuthFailed) || !errors.As(err, &apiErr) || apiErr.Code != "390144" {
+		t.Errorf("expected auth failure with API details, got: %v", err)
+	}
+
+	status = http.StatusNotFound
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrInvalidAccount) {
+		t.Errorf("expected invalid account, got: %v", err)
+	}
+}
+
+func TestPing_NetworkFailures(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	client.httpClient.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: "bad.snowflakecomputing.com", IsNotFound: true}
+	})
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrInvalidAccount) {
+		t.Errorf("expected invalid account for DNS failure, got: %v", err)
+	}
+
+	client.httpClient.Transport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	})
+	if err := client.Ping(context.Background()); !errors.Is(err, ErrUnreachable) {
+		t.Errorf("expected unreachable, got: %v", err)
+	}
+}
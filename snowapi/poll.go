@@ -0,0 +1,90 @@
+package snowapi
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// PollStrategy controls the delay between status polls while waiting for a
+// statement. Delays start at Initial and grow by Multiplier up to Max; when
+// Initial equals Max the interval is constant. Jitter (0-1) randomizes each
+// delay by up to that fraction so concurrent waiters do not poll in lockstep.
+type PollStrategy struct {
+	Initial    time.Duration // first delay (default 500ms)
+	Max        time.Duration // upper bound for any delay (default 5s)
+	Multiplier float64       // growth factor (default 1.5)
+	Jitter     float64       // fraction of each delay to randomize, 0 disables
+}
+
+// DefaultPollStrategy is used by WaitUntilCompleteWithStrategy for zero fields.
+var DefaultPollStrategy = PollStrategy{
+	Initial:    500 * time.Millisecond,
+	Max:        5 * time.Second,
+	Multiplier: 1.5,
+}
+
+// isZero reports whether no field of p has been set.
+func (p PollStrategy) isZero() bool {
+	return p == PollStrategy{}
+}
+
+// withDefaults fills unset fields from DefaultPollStrategy.
+func (p PollStrategy) withDefaults() PollStrategy {
+	if p.Initial <= 0 {
+		p.Initial = DefaultPollStrategy.Initial
+	}
+	if p.Max <= 0 {
+		p.Max = DefaultPollStrategy.Max
+	}
+	if p.Max < p.Initial {
+		p.Max = p.Initial
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultPollStrategy.Multiplier
+	}
+	return p
+}
+
+// nominal returns the un-jittered delay after the given poll (1-based).
+func (p PollStrategy) nominal(poll int) time.Duration {
+	if p.Initial == p.Max {
+		return p.Initial
+	}
+	d := time.Duration(float64(p.Initial) * math.Pow(p.Multiplier, float64(poll-1)))
+	if d > p.Max || d <= 0 {
+		d = p.Max
+	}
+	return d
+}
+
+// delay returns the jittered delay after the given poll (1-based).
+func (p PollStrategy) delay(poll int) time.Duration {
+	d := p.nominal(poll)
+	if p.Jitter > 0 {
+		j := math.Min(p.Jitter, 1)
+		d = time.Duration(float64(d) * (1 + j*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// pollsFor returns how many polls the strategy needs to span total.
+func (p PollStrategy) pollsFor(total time.Duration) int {
+	var elapsed time.Duration
+	polls := 0
+	for elapsed < total {
+		polls++
+		elapsed += p.nominal(polls)
+	}
+	if polls == 0 {
+		polls = 1
+	}
+	return polls
+}
+
+// WaitUntilCompleteWithStrategy is like WaitUntilCompleteContext but spaces
+// polls according to strategy instead of a fixed interval.
+func (c *Client) WaitUntilCompleteWithStrategy(ctx context.Context, handle string, strategy PollStrategy, maxPolls int) (*QueryResponse, error) {
+	return c.waitUntilComplete(ctx, handle, strategy.withDefaults(), maxPolls)
+}
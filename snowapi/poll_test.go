@@ -0,0 +1,88 @@
+package snowapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestPollStrategy_Delay(t *testing.T) {
+	p := PollStrategy{Initial: 100 * time.Millisecond, Max: 400 * time.Millisecond, Multiplier: 2}.withDefaults()
+	want := []time.Duration{100, 200, 400, 400}
+	for i, w := range want {
+		if got := p.delay(i + 1); got != w*time.Millisecond {
+			t.Errorf("poll %d: expected %v, got %v", i+1, w*time.Millisecond, got)
+		}
+	}
+
+	constant := PollStrategy{Initial: time.Second, Max: time.Second}.withDefaults()
+	if constant.delay(5) != time.Second {
+		t.Errorf("expected constant interval, got %v", constant.delay(5))
+	}
+
+	jittered := PollStrategy{Initial: time.Second, Max: time.Second, Jitter: 0.5}
+	for i := 0; i < 20; i++ {
+		if d := jittered.delay(1); d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("jittered delay out of range: %v", d)
+		}
+	}
+}
+
+func TestPollStrategy_PollsFor(t *testing.T) {
+	p := PollStrategy{Initial: time.Second, Max: 4 * time.Second, Multiplier: 2}
+	// 1 + 2 + 4 + 4 = 11s
+	if n := p.pollsFor(10 * time.Second); n != 4 {
+		t.Errorf("expected 4 polls, got %d", n)
+	}
+}
+
+func TestWaitUntilCompleteWithStrategy(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 3 {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334"}`))
+			return
+		}
+		w.Write([]byte(`{"statementHandle":"test-handle","data":[["1"]]}`))
+	})
+
+	strategy := PollStrategy{Initial: time.Millisecond, Max: 5 * time.Millisecond, Jitter: 0.2}
+	resp, err := client.WaitUntilCompleteWithStrategy(context.Background(), "test-handle", strategy, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 || len(resp.Data) != 1 {
+		t.Errorf("expected completion on third poll, got %d polls", polls)
+	}
+}
+
+func TestWaitForCompletion(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		if polls < 10 {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334"}`))
+			return
+		}
+		w.Write([]byte(`{"statementHandle":"h1","data":[["1"]]}`))
+	})
+	client.config.PollStrategy = PollStrategy{Initial: time.Millisecond, Max: time.Millisecond}
+
+	final := &QueryResponse{Code: "090001", StatementHandle: "h1"}
+	if resp, err := client.WaitForCompletion(context.Background(), final, nil); resp != final || err != nil || polls != 0 {
+		t.Errorf("expected a final response to be returned as is, got %v (err %v, %d polls)", resp, err, polls)
+	}
+
+	running := &QueryResponse{Code: "333334", StatementHandle: "h1"}
+	resp, err := client.WaitForCompletion(context.Background(), running, &RequestOptions{StatementTimeout: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 10 || len(resp.Data) != 1 {
+		t.Errorf("expected completion on poll 10 per the client's strategy, got %d polls", polls)
+	}
+}
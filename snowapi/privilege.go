@@ -0,0 +1,66 @@
+package snowapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// sqlStateInsufficientPrivileges is the SQLState of access control errors.
+const sqlStateInsufficientPrivileges = "42501"
+
+// privilegeObjectRe extracts the object from messages like
+// "Insufficient privileges to operate on table 'ORDERS'".
+var privilegeObjectRe = regexp.MustCompile(`(?i)privileges? to operate on (?:(\w+(?: \w+)?) )?'([^']+)'`)
+
+// PrivilegeError reports a statement rejected for insufficient privileges
+// (SQLState 42501). Role is the role the statement ran under (empty for the
+// user's default role); ObjectType and Object are parsed from the message
+// when Snowflake names them. It unwraps to the underlying *APIError.
+type PrivilegeError struct {
+	APIError
+	Role       string
+	ObjectType string // e.g. "table", "schema"
+	Object     string // e.g. "ORDERS"
+}
+
+func (e *PrivilegeError) Error() string {
+	var b strings.Builder
+	b.WriteString("insufficient privileges")
+	if e.Object != "" {
+		b.WriteString(" on ")
+		if e.ObjectType != "" {
+			b.WriteString(e.ObjectType + " ")
+		}
+		b.WriteString(e.Object)
+	}
+	if e.Role != "" {
+		b.WriteString(" for role " + e.Role)
+	}
+	fmt.Fprintf(&b, ": %s", strings.TrimSpace(e.Message))
+	if e.Code != "" {
+		fmt.Fprintf(&b, " (code %s)", e.Code)
+	}
+	return b.String()
+}
+
+func (e *PrivilegeError) Unwrap() error { return &e.APIError }
+
+func newPrivilegeError(apiErr *APIError) *PrivilegeError {
+	e := &PrivilegeError{APIError: *apiErr}
+	if m := privilegeObjectRe.FindStringSubmatch(apiErr.Message); m != nil {
+		e.ObjectType = strings.ToLower(m[1])
+		e.Object = m[2]
+	}
+	return e
+}
+
+// withRole records role on a *PrivilegeError in err's chain.
+func withRole(err error, role string) error {
+	var pe *PrivilegeError
+	if role != "" && errors.As(err, &pe) && pe.Role == "" {
+		pe.Role = role
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestExecute_PrivilegeError(t *testing.T) {
+	var role string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body QueryRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		role = body.Role
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"003001","sqlState":"42501","message":"SQL access control error:\nInsufficient privileges to operate on table 'ORDERS'","statementHandle":"h1"}`))
+	})
+	client.config.Role = "PUBLIC"
+
+	_, err := client.Execute("SELECT * FROM orders", false, &RequestOptions{Role: "ANALYST"})
+	if role != "ANALYST" {
+		t.Errorf("expected the per-statement role in the body, got %q", role)
+	}
+	var pe *PrivilegeError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expected *PrivilegeError, got %T: %v", err, err)
+	}
+	if pe.Role != "ANALYST" || pe.ObjectType != "table" || pe.Object != "ORDERS" {
+		t.Errorf("unexpected privilege error fields: %+v", pe)
+	}
+	want := "insufficient privileges on table ORDERS for role ANALYST: SQL access control error:\nInsufficient privileges to operate on table 'ORDERS' (code 003001)"
+	if err.Error() != want {
+		t.Errorf("unexpected message:\n%s\nwant:\n%s", err.Error(), want)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.SQLState != "42501" {
+		t.Errorf("expected to unwrap to *APIError, got %v", err)
+	}
+}
+
+func TestWait_PrivilegeError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"003001","sqlState":"42501","message":"Insufficient privileges to operate on schema 'RAW'"}`))
+	})
+	client.config.Role = "LOADER"
+
+	_, err := client.ExecuteAndWait("CREATE TABLE raw.t (id INT)", nil)
+	var pe *PrivilegeError
+	if !errors.As(err, &pe) || pe.Role != "LOADER" || pe.ObjectType != "schema" || pe.Object != "RAW" {
+		t.Fatalf("expected privilege error for schema RAW under LOADER, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package snowapi
+
+import "time"
+
+// QueryStats summarizes a result for cost and performance insight. Sizes
+// and row counts cover every partition listed in the response, so take them
+// from the response of the statement itself rather than of a later
+// partition fetch, which carries no partition info.
+type QueryStats struct {
+	Partitions        int
+	TotalRows         int64 // sum of partition row counts, else numRows
+	UncompressedBytes int64
+	CompressedBytes   int64 // partitions that report no compressed size count as uncompressed
+
+	// CreatedOn is when Snowflake registered the statement and Elapsed the
+	// wall-clock time from then until Stats was called; both are zero when
+	// the response has no createdOn.
+	CreatedOn time.Time
+	Elapsed   time.Duration
+
+	// Row counts for DML statements; zero otherwise.
+	RowsInserted         int64
+	RowsUpdated          int64
+	RowsDeleted          int64
+	DuplicateRowsUpdated int64
+}
+
+// Stats derives QueryStats from the response's metadata.
+func (r *QueryResponse) Stats() QueryStats {
+	return r.stats(time.Now())
+}
+
+func (r *QueryResponse) stats(now time.Time) QueryStats {
+	partitions := r.ResultSetMetaData.PartitionInfo
+	s := QueryStats{Partitions: len(partitions)}
+	for _, p := range partitions {
+		s.TotalRows += int64(p.RowCount)
+		s.UncompressedBytes += int64(p.UncompressedSize)
+		if p.CompressedSize != nil {
+			s.CompressedBytes += int64(*p.CompressedSize)
+		} else {
+			s.CompressedBytes += int64(p.UncompressedSize)
+		}
+	}
+	if len(partitions) == 0 {
+		s.TotalRows = int64(r.ResultSetMetaData.NumRows)
+	}
+	if !r.CreatedOnTime().IsZero() {
+		s.CreatedOn = r.CreatedOnTime()
+		s.Elapsed = now.Sub(s.CreatedOn)
+	}
+	if d := r.DMLStats; d != nil {
+		s.RowsInserted = d.RowsInserted
+		s.RowsUpdated = d.RowsUpdated
+		s.RowsDeleted = d.RowsDeleted
+		s.DuplicateRowsUpdated = d.DuplicateRowsUpdated
+	}
+	return s
+}
+
+// CreatedOnTime returns CreatedOn as a UTC time, or the zero time when it is
+// unset. Snowflake reports epoch milliseconds; seconds, microseconds and
+// nanoseconds are recognized by magnitude, so any timestamp between 1973 and
+// 5138 converts correctly whatever the unit.
+func (r *QueryResponse) CreatedOnTime() time.Time {
+	v := r.CreatedOn
+	switch {
+	case v <= 0:
+		return time.Time{}
+	case v < 1e11:
+		return time.Unix(v, 0).UTC()
+	case v < 1e14:
+		return time.UnixMilli(v).UTC()
+	case v < 1e17:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
@@ -0,0 +1,69 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestQueryResponse_Stats(t *testing.T) {
+	var resp QueryResponse
+	body := `{
+		"createdOn": 1710498645000,
+		"resultSetMetaData": {"numRows": 7, "partitionInfo": [
+			{"rowCount": 5, "uncompressedSize": 1000, "compressedSize": 200},
+			{"rowCount": 2, "uncompressedSize": 300}
+		]},
+		"stats": {"numRowsInserted": 4, "numRowsUpdated": 3}
+	}`
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	created := time.Date(2024, 3, 15, 10, 30, 45, 0, time.UTC)
+	got := resp.stats(created.Add(90 * time.Second))
+	want := QueryStats{
+		Partitions:        2,
+		TotalRows:         7,
+		UncompressedBytes: 1300,
+		CompressedBytes:   500,
+		CreatedOn:         created,
+		Elapsed:           90 * time.Second,
+		RowsInserted:      4,
+		RowsUpdated:       3,
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestQueryResponse_StatsWithoutMetadata(t *testing.T) {
+	resp := &QueryResponse{ResultSetMetaData: ResultSetMetaData{NumRows: 3}}
+	if got := resp.Stats(); got != (QueryStats{TotalRows: 3}) {
+		t.Errorf("unexpected stats: %+v", got)
+	}
+}
+
+func TestQueryResponse_CreatedOnTime(t *testing.T) {
+	want := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)
+	tests := []struct {
+		name      string
+		createdOn int64
+		want      time.Time
+	}{
+		{"unset", 0, time.Time{}},
+		{"negative", -1, time.Time{}},
+		{"seconds", 1710498645, want.Truncate(time.Second)},
+		{"milliseconds", 1710498645123, want},
+		{"microseconds", 1710498645123000, want},
+		{"nanoseconds", 1710498645123000000, want},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &QueryResponse{CreatedOn: tt.createdOn}
+			if got := resp.CreatedOnTime(); !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("CreatedOnTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package snowapi
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// throttle paces outgoing requests with a token bucket and pauses all
+// callers after a 429 for as long as the server asked.
+type throttle struct {
+	limiter *rate.Limiter
+
+	mu          sync.Mutex
+	pausedUntil time.Time
+}
+
+func newThrottle(rps float64) *throttle {
+	burst := int(math.Ceil(rps))
+	if burst < 1 {
+		burst = 1
+	}
+	return &throttle{limiter: rate.NewLimiter(rate.Limit(rps), burst)}
+}
+
+// wait blocks until any 429 pause has elapsed and a token is available.
+func (t *throttle) wait(ctx context.Context) error {
+	t.mu.Lock()
+	pause := time.Until(t.pausedUntil)
+	t.mu.Unlock()
+
+	if pause > 0 {
+		timer := time.NewTimer(pause)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return t.limiter.Wait(ctx)
+}
+
+// backOff pauses every caller for at least d.
+func (t *throttle) backOff(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if until := time.Now().Add(d); until.After(t.pausedUntil) {
+		t.pausedUntil = until
+	}
+}
+
+// Limiter returns the client-side rate limiter configured via
+// Config.RequestsPerSecond, or nil when rate limiting is disabled.
+func (c *Client) Limiter() *rate.Limiter {
+	if c.throttle == nil {
+		return nil
+	}
+	return c.throttle.limiter
+}
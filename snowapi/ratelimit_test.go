@@ -0,0 +1,58 @@
+package snowapi
+
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_PacesBurst(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	client.throttle = newThrottle(20)
+	client.Limiter().SetBurst(1)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if _, _, err := client.Poll("test-handle", 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// 5 requests at 20/s with a burst of 1 need at least 4 intervals of 50ms.
+	if elapsed := time.Since(start); elapsed < 190*time.Millisecond {
+		t.Errorf("expected requests to be paced, took %v", elapsed)
+	}
+}
+
+func TestRateLimiter_BacksOffOn429(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"code":"090001"}`))
+	})
+	client.throttle = newThrottle(1000)
+
+	client.Poll("test-handle", 0)
+
+	start := time.Now()
+	if _, _, err := client.Poll("test-handle", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("expected the next request to wait for Retry-After, took %v", elapsed)
+	}
+}
+
+func TestLimiter_NilWhenDisabled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	if client.Limiter() != nil {
+		t.Error("expected no limiter without RequestsPerSecond")
+	}
+}
@@ -0,0 +1,119 @@
+package snowapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	// maxBodySnippet bounds how much of an undecodable body is quoted in errors.
+	maxBodySnippet = 512
+	// maxCapturedResponse bounds the body retained by CaptureRawResponses.
+	maxCapturedResponse = 64 << 10
+)
+
+// rawCapture holds the most recent response body when
+// Config.CaptureRawResponses is set.
+type rawCapture struct {
+	mu   sync.Mutex
+	body []byte
+}
+
+// LastRawResponse returns a copy of the most recent response body (truncated
+// to 64 KiB), or nil unless Config.CaptureRawResponses is set. Response
+// bodies never contain the auth token, but may contain result data.
+func (c *Client) LastRawResponse() []byte {
+	c.raw.mu.Lock()
+	defer c.raw.mu.Unlock()
+	if c.raw.body == nil {
+		return nil
+	}
+	return append([]byte(nil), c.raw.body...)
+}
+
+// decodeBody reads resp fully and unmarshals it into v. A success response
+// with an empty body (e.g. a 204) leaves v unchanged; any other body that
+// fails to decode is an error quoting the start of the body and the HTTP
+// status.
+func (c *Client) decodeBody(resp *http.Response, v any) error {
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body (status %d): %w", resp.StatusCode, err)
+	}
+
+	if c.config.CaptureRawResponses {
+		kept := b
+		if len(kept) > maxCapturedResponse {
+			kept = kept[:maxCapturedResponse]
+		}
+		c.raw.mu.Lock()
+		c.raw.body = append(c.raw.body[:0], kept...)
+		c.raw.mu.Unlock()
+	}
+
+	if isSuccessStatus(resp.StatusCode) && len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+
+	// UseNumber keeps numbers decoded into interface values exact.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return &bodyDecodeError{status: resp.StatusCode, snippet: bodySnippet(b), err: err}
+	}
+	return nil
+}
+
+// bodyDecodeError is a response body decodeBody could not decode.
+type bodyDecodeError struct {
+	status  int
+	snippet string
+	err     error
+}
+
+func (e *bodyDecodeError) Error() string {
+	return fmt.Sprintf("status %d, body %q: %v", e.status, e.snippet, e.err)
+}
+
+func (e *bodyDecodeError) Unwrap() error { return e.err }
+
+// undecodedMessage describes an error response whose body failed to decode
+// with err, quoting the start of the body (e.g. a proxy's HTML error page).
+func undecodedMessage(status int, err error) string {
+	var decodeErr *bodyDecodeError
+	if errors.As(err, &decodeErr) && strings.TrimSpace(decodeErr.snippet) != "" {
+		return fmt.Sprintf("status %d: %s", status, strings.TrimSpace(decodeErr.snippet))
+	}
+	return fmt.Sprintf("status %d", status)
+}
+
+// isSuccessStatus reports whether status is 2xx.
+func isSuccessStatus(status int) bool {
+	return status >= http.StatusOK && status < http.StatusMultipleChoices
+}
+
+// isCompletedStatus reports whether status means a statement finished
+// successfully: 200, or another 2xx except 202 (still running), such as the
+// 204 some gateways return for statements without results.
+func isCompletedStatus(status int) bool {
+	return isSuccessStatus(status) && status != http.StatusAccepted
+}
+
+// bodySnippet truncates b for inclusion in an error message.
+func bodySnippet(b []byte) string {
+	if len(b) <= maxBodySnippet {
+		return string(b)
+	}
+	cut := maxBodySnippet
+	for cut > 0 && !utf8.RuneStart(b[cut]) {
+		cut--
+	}
+	return string(b[:cut]) + "..."
+}
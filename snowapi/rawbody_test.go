@@ -0,0 +1,136 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeFailure_IncludesBodySnippet(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`<html>proxy login required</html>` + strings.Repeat("x", 2000)))
+	})
+
+	_, _, err := client.Poll("h1", 0)
+	if err == nil {
+		t.Fatal("expected decode error")
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "status 200") || !strings.Contains(msg, "proxy login required") {
+		t.Errorf("expected status and body in error: %s", msg)
+	}
+	if len(msg) > maxBodySnippet+200 {
+		t.Errorf("expected body to be truncated, got %d bytes", len(msg))
+	}
+	if client.LastRawResponse() != nil {
+		t.Error("expected no capture unless CaptureRawResponses is set")
+	}
+
+	// Error responses keep the snippet in the APIError message.
+	gateway := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte(`<html>502 Bad Gateway from lb-7</html>`))
+	})
+	for name, err := range map[string]error{
+		"execute": func() error { _, err := gateway.Execute("SELECT 1", false, nil); return err }(),
+		"cancel":  gateway.Cancel("h1"),
+	} {
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadGateway {
+			t.Fatalf("%s: expected an APIError with status 502, got %v", name, err)
+		}
+		if want := "status 502: <html>502 Bad Gateway from lb-7</html>"; apiErr.Message != want {
+			t.Errorf("%s: message = %q, want %q", name, apiErr.Message, want)
+		}
+	}
+}
+
+func TestCaptureRawResponses(t *testing.T) {
+	body := `{"statementHandle":"h1","data":[["1"]]}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+	client.config.CaptureRawResponses = true
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := string(client.LastRawResponse()); got != body {
+		t.Errorf("expected captured body %s, got %s", body, got)
+	}
+
+	body = `{"data":[["` + strings.Repeat("y", maxCapturedResponse) + `"]]}`
+	if _, err := client.Execute("SELECT 2", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := len(client.LastRawResponse()); n != maxCapturedResponse {
+		t.Errorf("expected capture capped at %d bytes, got %d", maxCapturedResponse, n)
+	}
+}
+
+func TestExecute_EmptySuccessBody(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"empty 200", http.StatusOK, ""},
+		{"whitespace 200", http.StatusOK, " \n"},
+		{"203", http.StatusNonAuthoritativeInfo, ""},
+		{"204", http.StatusNoContent, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			resp, err := client.Execute("CREATE TABLE t (id INT)", false, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.HTTPStatus != tt.status || resp.Code != "" || len(resp.Data) != 0 {
+				t.Errorf("expected an empty response with status %d, got %+v", tt.status, resp)
+			}
+		})
+	}
+}
+
+func TestExecute_MalformedSuccessBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":`))
+	})
+
+	_, err := client.Execute("SELECT 1", false, nil)
+	if err == nil || !strings.Contains(err.Error(), "failed to decode response") {
+		t.Errorf("expected a decode error for a truncated body, got %v", err)
+	}
+}
+
+func TestExecute_EmptyErrorBody(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err := client.Execute("SELECT 1", false, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusBadRequest {
+		t.Errorf("expected an APIError with status 400, got %v", err)
+	}
+}
+
+func TestWaitUntilComplete_NoContent(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	resp, err := client.WaitUntilComplete("h1", time.Millisecond, 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.HTTPStatus != http.StatusNoContent {
+		t.Errorf("expected status 204, got %d", resp.HTTPStatus)
+	}
+}
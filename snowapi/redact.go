@@ -0,0 +1,112 @@
+package snowapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// secretFields are struct fields whose contents are never printed.
+var secretFields = map[string]bool{
+	"PrivateKey":  true,
+	"PublicKey":   true,
+	"Passphrase":  true,
+	"AccessToken": true,
+}
+
+// String formats the config like %+v with key material and secret Headers
+// values masked.
+func (c Config) String() string { return redactedString(reflect.ValueOf(c), false) }
+
+// GoString is like String but formats like %#v.
+func (c Config) GoString() string { return redactedString(reflect.ValueOf(c), true) }
+
+// String formats the authenticator with key material masked.
+func (a *KeyPairAuthenticator) String() string {
+	return "&" + redactedString(reflect.ValueOf(a).Elem(), false)
+}
+
+// String formats the authenticator with the access token masked.
+func (a *OAuthAuthenticator) String() string {
+	return "&" + redactedString(reflect.ValueOf(a).Elem(), false)
+}
+
+// redactedString renders the exported fields of struct v, masking
+// secretFields. Pointers, interfaces and funcs render as their type only so
+// nested values (e.g. an Authenticator) cannot leak through.
+func redactedString(v reflect.Value, goSyntax bool) string {
+	t := v.Type()
+	sep := " "
+	if goSyntax {
+		sep = ", "
+	}
+
+	var b strings.Builder
+	if goSyntax {
+		b.WriteString(t.String())
+	}
+	b.WriteByte('{')
+	first := true
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		if !first {
+			b.WriteString(sep)
+		}
+		first = false
+		b.WriteString(f.Name)
+		b.WriteByte(':')
+		b.WriteString(redactedField(f.Name, v.Field(i), goSyntax))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func redactedField(name string, fv reflect.Value, goSyntax bool) string {
+	if secretFields[name] {
+		n := fv.Len()
+		if n == 0 {
+			return "[]"
+		}
+		if fv.Kind() == reflect.String {
+			return "[REDACTED]"
+		}
+		return fmt.Sprintf("[REDACTED %d bytes]", n)
+	}
+	if headers, ok := fv.Interface().(map[string]string); ok && name == "Headers" {
+		fv = reflect.ValueOf(redactedHeaders(headers))
+	}
+
+	switch fv.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Func:
+		if fv.IsNil() {
+			return "<nil>"
+		}
+		if fv.Kind() == reflect.Interface {
+			return fmt.Sprintf("%T", fv.Interface())
+		}
+		return fv.Type().String()
+	}
+	if goSyntax {
+		return fmt.Sprintf("%#v", fv.Interface())
+	}
+	return fmt.Sprintf("%+v", fv.Interface())
+}
+
+// redactedHeaders returns a copy of headers with the values of the names
+// isSecretHeader matches masked, as in HTTP dumps.
+func redactedHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if isSecretHeader(name) {
+			value = "[REDACTED]"
+		}
+		out[name] = value
+	}
+	return out
+}
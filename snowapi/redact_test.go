@@ -0,0 +1,70 @@
+package snowapi
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestConfig_StringRedactsKeyMaterial(t *testing.T) {
+	priv, pub := testKeyPair(t)
+	cfg := Config{
+		Account:    "TESTACCT",
+		User:       "TESTUSER",
+		PrivateKey: priv,
+		PublicKey:  pub,
+		Passphrase: []byte("hunter2-passphrase"),
+		Headers: map[string]string{
+			"Proxy-Authorization": "Basic cHJveHk6c2VjcmV0",
+			"X-Api-Key":           "gateway-api-key",
+			"X-Team":              "analytics",
+		},
+		Authenticator: &KeyPairAuthenticator{
+			PrivateKey: priv,
+			PublicKey:  pub,
+		},
+	}
+
+	secrets := []string{
+		string(priv[40:80]),
+		string(pub[40:80]),
+		"hunter2-passphrase",
+		"cHJveHk6c2VjcmV0",
+		"gateway-api-key",
+	}
+	for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+		out := fmt.Sprintf(format, cfg)
+		for _, secret := range secrets {
+			if strings.Contains(out, secret) {
+				t.Errorf("%s leaked key material: %s", format, out)
+			}
+		}
+		if !strings.Contains(out, "TESTACCT") || !strings.Contains(out, "analytics") {
+			t.Errorf("%s dropped non-secret fields: %s", format, out)
+		}
+	}
+
+	out := fmt.Sprintf("%+v", cfg)
+	if want := fmt.Sprintf("PrivateKey:[REDACTED %d bytes]", len(priv)); !strings.Contains(out, want) {
+		t.Errorf("expected %q in %s", want, out)
+	}
+	if want := "Proxy-Authorization:[REDACTED]"; !strings.Contains(out, want) {
+		t.Errorf("expected %q in %s", want, out)
+	}
+	if !strings.HasPrefix(fmt.Sprintf("%#v", cfg), "snowapi.Config{") {
+		t.Errorf("unexpected GoString: %#v", cfg)
+	}
+}
+
+func TestAuthenticator_StringRedactsSecrets(t *testing.T) {
+	priv, _ := testKeyPair(t)
+	for _, a := range []any{
+		&KeyPairAuthenticator{Account: "A", PrivateKey: priv},
+		&OAuthAuthenticator{AccessToken: "secret-oauth-token"},
+	} {
+		out := fmt.Sprintf("%+v", a)
+		if strings.Contains(out, "secret-oauth-token") || strings.Contains(out, string(priv[40:80])) {
+			t.Errorf("leaked secret: %s", out)
+		}
+	}
+}
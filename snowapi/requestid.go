@@ -0,0 +1,42 @@
+package snowapi
+
+import "sync"
+
+// maxTrackedRequestIDs bounds how many requestIds handleLog remembers.
+const maxTrackedRequestIDs = 1024
+
+// handleLog remembers the statement handle returned for recent requestIds so
+// a resubmission that Snowflake failed to deduplicate can be flagged.
+type handleLog struct {
+	mu      sync.Mutex
+	handles map[string]string
+	order   []string
+}
+
+// check records handle for requestID, logging when an earlier submission
+// with the same requestID produced a different handle.
+func (l *handleLog) check(logger Logger, requestID, handle string) {
+	if handle == "" {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if prev, ok := l.handles[requestID]; ok {
+		if prev != handle {
+			logger.Errorf("snowapi: requestId=%s returned handle %s but earlier returned %s; the statement may have run twice",
+				requestID, handle, prev)
+		}
+		return
+	}
+
+	if l.handles == nil {
+		l.handles = make(map[string]string)
+	}
+	if len(l.order) >= maxTrackedRequestIDs {
+		delete(l.handles, l.order[0])
+		l.order = l.order[1:]
+	}
+	l.handles[requestID] = handle
+	l.order = append(l.order, requestID)
+}
@@ -0,0 +1,63 @@
+package snowapi
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPollAndCancel_AttachRequestID(t *testing.T) {
+	seen := map[string]string{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		seen[r.Method+" "+r.URL.Path] = r.URL.Query().Get("requestId")
+		w.Write([]byte(`{"statementHandle":"h1"}`))
+	})
+
+	resp, _, err := client.Poll("h1", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := client.Cancel("h1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pollID := seen["GET /api/v2/statements/h1"]
+	if pollID == "" || resp.RequestID != pollID {
+		t.Errorf("expected poll requestId %q on response, got %q", pollID, resp.RequestID)
+	}
+	if seen["POST /api/v2/statements/h1/cancel"] == "" {
+		t.Errorf("expected cancel requestId, got %v", seen)
+	}
+}
+
+func TestExecute_WarnsWhenDedupFails(t *testing.T) {
+	handle := "h1"
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"statementHandle":"` + handle + `"}`))
+	})
+	logger := &recordingLogger{}
+	client.logger = logger
+
+	opts := &RequestOptions{RequestID: "7b6bd2e4-5d7e-4a8a-9c1f-3f0b3c2d1e00"}
+	resp, err := client.Execute("INSERT INTO t VALUES (1)", false, opts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.RequestID != opts.RequestID {
+		t.Errorf("expected requestId %s on response, got %s", opts.RequestID, resp.RequestID)
+	}
+	if _, err := client.Execute("INSERT INTO t VALUES (1)", false, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(logger.String(), "may have run twice") {
+		t.Errorf("unexpected warning for a stable handle:\n%s", logger)
+	}
+
+	handle = "h2"
+	if _, err := client.Execute("INSERT INTO t VALUES (1)", false, opts); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(logger.String(), "returned handle h2 but earlier returned h1") {
+		t.Errorf("expected dedup warning, got:\n%s", logger)
+	}
+}
@@ -0,0 +1,171 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrStatementRunning is returned by GetResults for a statement that has not
+// finished yet.
+var ErrStatementRunning = errors.New("statement is still running")
+
+// GetResults fetches the results of a completed statement by its handle,
+// e.g. one persisted by an earlier process. Snowflake keeps results for 24
+// hours. The response is partition 0 plus the result metadata; fetch the
+// remaining partitions with FetchPartitionRange or FetchAllPartitions.
+//
+// Unlike Poll, which reports the HTTP status for callers checking on a
+// running statement, GetResults treats only 200 as success: a statement
+// still running returns ErrStatementRunning, and failed or unknown
+// statements return an *APIError.
+func (c *Client) GetResults(handle string) (*QueryResponse, error) {
+	return c.GetResultsContext(context.Background(), handle)
+}
+
+// GetResultsContext is like GetResults but honors ctx.
+func (c *Client) GetResultsContext(ctx context.Context, handle string) (*QueryResponse, error) {
+	resp, status, err := c.PollContext(ctx, handle, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case http.StatusOK:
+		if resp.StatementHandle == "" {
+			resp.StatementHandle = handle
+		}
+		c.partitions.record(handle, resp.ResultSetMetaData.PartitionInfo)
+		return resp, nil
+	case http.StatusAccepted:
+		return nil, fmt.Errorf("statement %s: %w", handle, ErrStatementRunning)
+	}
+	if resp.StatementHandle == "" {
+		resp.StatementHandle = handle
+	}
+	return nil, statusError(fmt.Sprintf("get results failed with status %d", status), status, resp)
+}
+
+// Columns returns the metadata of each result column.
+func (r *QueryResponse) Columns() []ColumnMeta {
+	return r.ResultSetMetaData.RowType
+}
+
+// RowsAffected sums the "number of rows ..." columns Snowflake returns for
+// DML (e.g. "number of rows inserted", or both inserted and updated for a
+// MERGE). Statements without such columns, like DDL, report 0.
+func (r *QueryResponse) RowsAffected() (int64, error) {
+	if len(r.Data) == 0 {
+		return 0, nil
+	}
+	var total int64
+	for i, col := range r.Columns() {
+		if !strings.HasPrefix(strings.ToLower(col.Name), "number of rows") || i >= len(r.Data[0]) {
+			continue
+		}
+		v, err := DecodeValue(r.Data[0][i], col)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %q value: %w", col.Name, err)
+		}
+		switch n := v.(type) {
+		case int64:
+			total += n
+		case Decimal:
+			count, ok := n.Int64()
+			if !ok {
+				return 0, fmt.Errorf("invalid %q value %s", col.Name, n)
+			}
+			total += count
+		case string:
+			parsed, err := strconv.ParseInt(n, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid %q value %q", col.Name, n)
+			}
+			total += parsed
+		default:
+			return 0, fmt.Errorf("invalid %q value %v", col.Name, r.Data[0][i])
+		}
+	}
+	return total, nil
+}
+
+// ColumnNames returns the result column names in order.
+func (r *QueryResponse) ColumnNames() []string {
+	names := make([]string, len(r.ResultSetMetaData.RowType))
+	for i, col := range r.ResultSetMetaData.RowType {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// ColumnTypes returns the Snowflake type of each result column.
+func (r *QueryResponse) ColumnTypes() []string {
+	types := make([]string, len(r.ResultSetMetaData.RowType))
+	for i, col := range r.ResultSetMetaData.RowType {
+		types[i] = col.Type
+	}
+	return types
+}
+
+// RowMap zips each data row with the column names so values can be read as
+// row["CUSTOMER_ID"]. Values are left raw; NULLs appear as a nil value under
+// their key. Duplicate column names get a suffix for each repeat: ID, ID_2, ID_3.
+func (r *QueryResponse) RowMap() []map[string]any {
+	names := uniqueColumnNames(r.ColumnNames())
+
+	rows := make([]map[string]any, len(r.Data))
+	for i, row := range r.Data {
+		m := make(map[string]any, len(names))
+		for j, name := range names {
+			if j < len(row) {
+				m[name] = row[j]
+			}
+		}
+		rows[i] = m
+	}
+	return rows
+}
+
+// ToRecords is like RowMap but converts each value to its native Go type
+// with DecodeValue (int64, float64, Decimal, bool, time.Time, []byte,
+// decoded JSON), so the records can be encoded as JSON directly; Decimal
+// encodes as an exact JSON number. NULLs are nil values and duplicate column
+// names are suffixed as in RowMap.
+func (r *QueryResponse) ToRecords() ([]map[string]any, error) {
+	cols := r.ResultSetMetaData.RowType
+	names := uniqueColumnNames(r.ColumnNames())
+
+	records := make([]map[string]any, len(r.Data))
+	for i, row := range r.Data {
+		m := make(map[string]any, len(names))
+		for j, name := range names {
+			if j >= len(row) {
+				continue
+			}
+			v, err := DecodeValue(row[j], cols[j])
+			if err != nil {
+				return nil, fmt.Errorf("row %d, column %s: %w", i, name, err)
+			}
+			m[name] = v
+		}
+		records[i] = m
+	}
+	return records, nil
+}
+
+// uniqueColumnNames suffixes repeated names so every key is distinct.
+func uniqueColumnNames(names []string) []string {
+	seen := make(map[string]bool, len(names))
+	out := make([]string, len(names))
+	for i, name := range names {
+		candidate := name
+		for n := 2; seen[candidate]; n++ {
+			candidate = name + "_" + strconv.Itoa(n)
+		}
+		seen[candidate] = true
+		out[i] = candidate
+	}
+	return out
+}
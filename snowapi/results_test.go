@@ -0,0 +1,150 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestQueryResponse_ColumnAccessors(t *testing.T) {
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{
+			RowType: []ColumnMeta{
+				{Name: "ID", Type: "fixed"},
+				{Name: "NAME", Type: "text"},
+				{Name: "ID", Type: "fixed"},
+			},
+		},
+		Data: [][]any{{"1", nil, "10"}},
+	}
+
+	if got := resp.ColumnNames(); !reflect.DeepEqual(got, []string{"ID", "NAME", "ID"}) {
+		t.Errorf("unexpected names: %v", got)
+	}
+	if got := resp.ColumnTypes(); !reflect.DeepEqual(got, []string{"fixed", "text", "fixed"}) {
+		t.Errorf("unexpected types: %v", got)
+	}
+
+	rows := resp.RowMap()
+	want := map[string]any{"ID": "1", "NAME": nil, "ID_2": "10"}
+	if len(rows) != 1 || !reflect.DeepEqual(rows[0], want) {
+		t.Errorf("unexpected row map: %v", rows)
+	}
+	if _, ok := rows[0]["NAME"]; !ok {
+		t.Error("expected NULL column to be present with a nil value")
+	}
+}
+
+func TestQueryResponse_ToRecords(t *testing.T) {
+	scale := 2
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{
+			RowType: []ColumnMeta{
+				{Name: "ID", Type: "fixed"},
+				{Name: "PRICE", Type: "fixed", Scale: &scale},
+				{Name: "ACTIVE", Type: "boolean"},
+				{Name: "CREATED", Type: "timestamp_ntz"},
+				{Name: "ATTRS", Type: "object"},
+				{Name: "ID", Type: "fixed"},
+			},
+		},
+		Data: [][]any{
+			{"1", "9.50", "true", "1710498645.000000000", `{"k":"v"}`, "10"},
+			{"2", nil, nil, nil, nil, nil},
+		},
+	}
+
+	records, err := resp.ToRecords()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []map[string]any{
+		{
+			"ID": int64(1), "PRICE": 9.5, "ACTIVE": true,
+			"CREATED": time.Unix(1710498645, 0).UTC(),
+			"ATTRS":   map[string]any{"k": "v"}, "ID_2": int64(10),
+		},
+		{"ID": int64(2), "PRICE": nil, "ACTIVE": nil, "CREATED": nil, "ATTRS": nil, "ID_2": nil},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("unexpected records:\n got %v\nwant %v", records, want)
+	}
+
+	resp.Data = [][]any{{"x", nil, nil, nil, nil, nil}}
+	if _, err := resp.ToRecords(); err == nil || !strings.Contains(err.Error(), "row 0, column ID") {
+		t.Errorf("expected a conversion error naming the cell, got %v", err)
+	}
+}
+
+func TestQueryResponse_ToRecordsJSON(t *testing.T) {
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{
+			RowType: []ColumnMeta{
+				{Name: "ID", Type: "fixed", Precision: intPtr(38), Scale: intPtr(0)},
+				{Name: "AMOUNT", Type: "fixed", Precision: intPtr(38), Scale: intPtr(2)},
+			},
+		},
+		Data: [][]any{
+			{"42", "19.99"},
+			{"99999999999999999999", "12345678901234567890.25"},
+		},
+	}
+
+	records, err := resp.ToRecords()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := json.Marshal(records)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `[{"AMOUNT":19.99,"ID":42},{"AMOUNT":12345678901234567890.25,"ID":99999999999999999999}]`
+	if string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
+
+func TestGetResults(t *testing.T) {
+	status := http.StatusOK
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/h1") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(status)
+		switch status {
+		case http.StatusOK:
+			w.Write([]byte(`{"code":"090001","resultSetMetaData":{"partitionInfo":[{"rowCount":1},{"rowCount":1}]},"data":[["1"]]}`))
+		case http.StatusAccepted:
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+		default:
+			w.Write([]byte(`{"code":"000709","message":"Statement not found"}`))
+		}
+	})
+
+	resp, err := client.GetResults("h1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatementHandle != "h1" || len(resp.ResultSetMetaData.PartitionInfo) != 2 {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+	if n, err := client.PartitionCount("h1"); err != nil || n != 2 {
+		t.Errorf("expected remembered partition count 2, got %d (err %v)", n, err)
+	}
+
+	status = http.StatusAccepted
+	if _, err := client.GetResults("h1"); !errors.Is(err, ErrStatementRunning) {
+		t.Errorf("expected ErrStatementRunning, got %v", err)
+	}
+
+	status = http.StatusNotFound
+	_, err = client.GetResults("h1")
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "000709" || apiErr.StatementHandle != "h1" {
+		t.Errorf("expected *APIError for unknown handle, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// warehouseIdleThreshold matches Snowflake's default AUTO_SUSPEND: a
+// statement submitted after this long without one may find the warehouse
+// suspended and wait for it to resume.
+const warehouseIdleThreshold = 10 * time.Minute
+
+// resumeAttempts bounds submissions of a statement that fails transiently
+// while the warehouse may be resuming.
+const resumeAttempts = 3
+
+// httpClientKey carries a per-call *http.Client override for do.
+type httpClientKey struct{}
+
+// resumeWindow reports whether the next statement should be treated as the
+// first after idle: WarehouseResumeTimeout is set and nothing was submitted
+// successfully within warehouseIdleThreshold.
+func (c *Client) resumeWindow() bool {
+	if c.config.WarehouseResumeTimeout <= 0 {
+		return false
+	}
+	last := c.lastSubmit.Load()
+	return last == 0 || time.Since(time.Unix(0, last)) > warehouseIdleThreshold
+}
+
+// submitResuming submits body with the HTTP timeout raised to
+// WarehouseResumeTimeout, resubmitting up to resumeAttempts times on
+// transient failures. Every attempt carries the same requestId with
+// retry=true, so Snowflake runs the statement at most once.
+func (c *Client) submitResuming(ctx context.Context, body QueryRequest, async bool, opts *RequestOptions) (*QueryResponse, error) {
+	withID := RequestOptions{}
+	if opts != nil {
+		withID = *opts
+	}
+	if withID.RequestID == "" {
+		withID.RequestID = uuid.New().String()
+	}
+	// Resubmitting is only safe with deduplication; respect an explicit
+	// opt-out by sending the statement once.
+	attempts := resumeAttempts
+	if dedup, err := withID.dedupRetry(); err != nil {
+		return nil, err
+	} else if !dedup {
+		attempts = 1
+	}
+
+	ctx = context.WithValue(ctx, httpClientKey{}, c.resumeClient)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.postStatement(ctx, body, async, &withID)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !isResumeTransient(err) {
+			return resp, err
+		}
+
+		wait := c.config.Retry.backoff(attempt)
+		c.logger.Infof("snowapi: statement failed while the warehouse may be resuming, retrying in %v (attempt %d/%d): %v",
+			wait, attempt, attempts, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isResumeTransient reports whether err is a failure worth resubmitting
+// while a warehouse resumes: a transient HTTP status or a network timeout.
+func isResumeTransient(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return isRetryableStatus(apiErr.HTTPStatus)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// newResumeClient returns hc with its timeout raised to at least timeout,
+// sharing hc's transport.
+func newResumeClient(hc *http.Client, timeout time.Duration) *http.Client {
+	if timeout <= 0 || hc.Timeout == 0 || hc.Timeout >= timeout {
+		return hc
+	}
+	rc := *hc
+	rc.Timeout = timeout
+	return &rc
+}
+
+// httpClientFor returns the HTTP client for a request made under ctx.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	if hc, ok := ctx.Value(httpClientKey{}).(*http.Client); ok && hc != nil {
+		return hc
+	}
+	return c.httpClient
+}
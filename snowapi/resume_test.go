@@ -0,0 +1,66 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestWarehouseResume_FirstStatement(t *testing.T) {
+	var requestIDs, retries []string
+	calls := 0
+	fail := true
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requestIDs = append(requestIDs, r.URL.Query().Get("requestId"))
+		retries = append(retries, r.URL.Query().Get("retry"))
+		calls++
+		if calls == 1 {
+			// Resuming warehouse: slower than the regular HTTP timeout.
+			time.Sleep(100 * time.Millisecond)
+		}
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message":"warehouse is resuming"}`))
+			fail = len(requestIDs) < 2
+			return
+		}
+		w.Write([]byte(`{"code":"090001","statementHandle":"h1","data":[["1"]]}`))
+	})
+	client.httpClient.Timeout = 50 * time.Millisecond
+	client.config.WarehouseResumeTimeout = time.Second
+	client.config.Retry.InitialBackoff = time.Millisecond
+	client.resumeClient = newResumeClient(client.httpClient, client.config.WarehouseResumeTimeout)
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(requestIDs) != 3 {
+		t.Fatalf("expected 3 submissions, got %d", len(requestIDs))
+	}
+	for i := range requestIDs {
+		if requestIDs[i] == "" || requestIDs[i] != requestIDs[0] || retries[i] != "true" {
+			t.Errorf("submission %d: requestId=%q retry=%q, want the same requestId with retry=true", i, requestIDs[i], retries[i])
+		}
+	}
+
+	// The warehouse is warm now: no extended timeout, no resubmission.
+	requestIDs = nil
+	fail = true
+	_, err := client.Execute("SELECT 1", false, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatus != http.StatusServiceUnavailable || len(requestIDs) != 1 {
+		t.Errorf("expected a single failed submission, got %v after %d submissions", err, len(requestIDs))
+	}
+}
+
+func TestWarehouseResume_Disabled(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	if _, err := client.Execute("SELECT 1", false, nil); err == nil || calls != 1 {
+		t.Errorf("expected a single failed submission, got %v after %d calls", err, calls)
+	}
+}
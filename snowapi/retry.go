@@ -0,0 +1,234 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryConfig controls retries of transient HTTP failures (429, 500, 502, 503,
+// 504 and connection errors). The zero value disables retries.
+type RetryConfig struct {
+	MaxAttempts    int           // total attempts including the first; <= 1 disables retries
+	InitialBackoff time.Duration // delay before the first retry (default 500ms)
+	MaxBackoff     time.Duration // upper bound for any single delay (default 10s)
+	Multiplier     float64       // backoff growth factor (default 2)
+}
+
+// backoff returns the delay before the given retry (1-based).
+func (rc RetryConfig) backoff(retry int) time.Duration {
+	initial := rc.InitialBackoff
+	if initial <= 0 {
+		initial = 500 * time.Millisecond
+	}
+	maxBackoff := rc.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = 10 * time.Second
+	}
+	multiplier := rc.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := time.Duration(float64(initial) * math.Pow(multiplier, float64(retry-1)))
+	if d > maxBackoff || d <= 0 {
+		d = maxBackoff
+	}
+	return d
+}
+
+// isRetryableStatus reports whether an HTTP status is worth retrying.
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// do sends req for operation op, retrying transient failures per
+// Config.Retry when retryable is set: retryable statuses and transient
+// network errors, but not unknown hosts or certificate errors (see
+// IsRetryable). The request body is replayed via
+// req.GetBody on each retry. A 401 or 403 is retried once, regardless of
+// retryable, after forcing a token refresh when the Authenticator supports
+// it; a second rejection returns an *AuthError. While the circuit breaker
+// is open it fails with ErrCircuitOpen without sending anything.
+func (c *Client) do(req *http.Request, op string, retryable bool) (resp *http.Response, err error) {
+	if err := c.checkOpen(); err != nil {
+		return nil, err
+	}
+	if err := c.breaker.allow(); err != nil {
+		c.metrics.IncError(op, "circuit_open")
+		return nil, err
+	}
+	defer func() { c.breaker.done(req.Context(), resp, err) }()
+
+	maxAttempts := c.config.Retry.MaxAttempts
+	if !retryable || maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	start := time.Now()
+	defer func() { c.metrics.ObserveRequestDuration(op, time.Since(start)) }()
+
+	ctx := req.Context()
+	httpClient := c.httpClientFor(ctx)
+	target := redactURL(req.URL)
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		if c.throttle != nil {
+			if err := c.throttle.wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		c.logger.Debugf("snowapi: %s %s (attempt %d/%d)", req.Method, target, attempt, maxAttempts)
+		c.dumpRequest(req)
+		resp, err := httpClient.Do(req)
+		if err == nil {
+			c.dumpResponse(resp)
+		}
+		if err == nil && isAuthStatus(resp.StatusCode) {
+			if refreshed {
+				c.metrics.IncError(op, strconv.Itoa(resp.StatusCode))
+				return nil, authRejected(resp)
+			}
+			if r, ok := c.auth.(tokenRefresher); ok {
+				// The server rejected the token, so the request did not run
+				// and can be replayed: mint a fresh token and try once more.
+				refreshed = true
+				io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				c.logger.Infof("snowapi: %s %s -> %d, refreshing token", req.Method, target, resp.StatusCode)
+				token, err := r.RefreshToken()
+				if err != nil {
+					return nil, &AuthError{Err: err}
+				}
+				req.Header.Set("Authorization", "Bearer "+token)
+				if err := rewindBody(req); err != nil {
+					return nil, err
+				}
+				attempt-- // not counted against Config.Retry
+				continue
+			}
+		}
+		if err == nil && resp.StatusCode == http.StatusTooManyRequests && c.throttle != nil {
+			pause, ok := retryAfter(resp)
+			if !ok {
+				pause = c.config.Retry.backoff(attempt)
+			}
+			c.throttle.backOff(pause)
+		}
+		if err != nil {
+			c.logger.Errorf("snowapi: %s %s failed: %v", req.Method, target, err)
+		} else {
+			c.logger.Debugf("snowapi: %s %s -> %d", req.Method, target, resp.StatusCode)
+		}
+
+		retry := attempt < maxAttempts && ctx.Err() == nil &&
+			(IsRetryable(err) || err == nil && isRetryableStatus(resp.StatusCode))
+		if !retry {
+			switch {
+			case err != nil:
+				c.metrics.IncError(op, "network")
+			case resp.StatusCode >= http.StatusBadRequest:
+				c.metrics.IncError(op, strconv.Itoa(resp.StatusCode))
+			}
+			if err != nil {
+				return nil, c.explainHostError(ctx, req.URL.Hostname(), err)
+			}
+			if err := gunzipResponse(resp); err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		wait := c.config.Retry.backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+			}
+			if c.throttle != nil && resp.StatusCode == http.StatusTooManyRequests {
+				wait = 0 // the throttle already enforces the pause
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		c.logger.Infof("snowapi: retrying %s %s in %v", req.Method, target, wait)
+		c.metrics.IncRetry(op)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if err := rewindBody(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// rewindBody resets req.Body via req.GetBody so req can be sent again.
+func rewindBody(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// isAuthStatus reports whether status means the server rejected the token.
+func isAuthStatus(status int) bool {
+	return status == http.StatusUnauthorized || status == http.StatusForbidden
+}
+
+// authRejected builds the AuthError for a response that still rejected the
+// token after a refresh, keeping Snowflake's error details when present.
+func authRejected(resp *http.Response) error {
+	defer resp.Body.Close()
+	var body QueryResponse
+	if err := gunzipResponse(resp); err != nil {
+		return &AuthError{Err: err, HTTPStatus: resp.StatusCode}
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body); err != nil || body.Message == "" {
+		body.Message = fmt.Sprintf("status %d", resp.StatusCode)
+	}
+	return &AuthError{Err: newAPIError("authentication failed", resp.StatusCode, &body), HTTPStatus: resp.StatusCode}
+}
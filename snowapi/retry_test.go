@@ -0,0 +1,182 @@
+package snowapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/vjain20/gosnowapi/internal/auth"
+)
+
+func TestPoll_RetriesTransientErrors(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"code":"090001","message":"ok"}`))
+	})
+	client.config.Retry = RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	resp, status, err := client.Poll("test-handle", 0)
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("expected success, got status %d, err %v", status, err)
+	}
+	if resp.Message != "ok" || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("unexpected result after %d calls: %+v", calls, resp)
+	}
+}
+
+func TestPoll_HonorsRetryAfter(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"message":"ok"}`))
+	})
+	client.config.Retry = RetryConfig{MaxAttempts: 2, InitialBackoff: time.Hour}
+
+	start := time.Now()
+	if _, _, err := client.Poll("test-handle", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Errorf("expected Retry-After to override the configured backoff")
+	}
+}
+
+func TestExecute_RetriesOnlyWithRequestID(t *testing.T) {
+	var calls int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"message":"unavailable"}`))
+	})
+	client.config.Retry = RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	if _, err := client.Execute("SELECT 1", false, nil); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("expected a single attempt without RequestID, got %d", n)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	if _, err := client.Execute("SELECT 1", false, &RequestOptions{RequestID: "req-1"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("expected 3 attempts with RequestID, got %d", n)
+	}
+}
+
+func TestRetryConfigBackoff(t *testing.T) {
+	rc := RetryConfig{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond, Multiplier: 2}
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, w := range want {
+		if got := rc.backoff(i + 1); got != w {
+			t.Errorf("retry %d: expected %v, got %v", i+1, w, got)
+		}
+	}
+}
+
+func TestDo_RefreshesTokenOnUnauthorized(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	var signed int32
+	orig := signJWT
+	signJWT = func(s *auth.Signer) (string, error) {
+		atomic.AddInt32(&signed, 1)
+		return orig(s)
+	}
+	defer func() { signJWT = orig }()
+
+	var auths []string
+	client.httpClient.Transport = roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		auths = append(auths, r.Header.Get("Authorization"))
+		if len(auths) == 1 {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Header: http.Header{},
+				Body: io.NopCloser(strings.NewReader(`{"code":"390144","message":"JWT token is invalid."}`))}, nil
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "SELECT 1") {
+			t.Errorf("request body not replayed: %q", body)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{},
+			Body: io.NopCloser(strings.NewReader(`{"code":"090001","data":[["1"]]}`))}, nil
+	})
+
+	if _, err := client.Execute("SELECT 1", false, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(auths) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(auths))
+	}
+	if n := atomic.LoadInt32(&signed); n != 2 {
+		t.Errorf("expected the cached token to be bypassed and re-signed, got %d signings", n)
+	}
+}
+
+func TestDo_UnauthorizedAfterRefresh(t *testing.T) {
+	var calls int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"code":"390144","message":"JWT token is invalid."}`))
+	})
+
+	_, err := client.Execute("SELECT 1", false, nil)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) || authErr.HTTPStatus != http.StatusForbidden {
+		t.Fatalf("expected *AuthError with status 403, got %T: %v", err, err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "390144" {
+		t.Errorf("expected API details, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected exactly one retry, got %d calls", calls)
+	}
+}
+
+func TestPoll_RetriesOnlyTransientNetworkErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		attempts int32
+	}{
+		{"connection reset", &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, 3},
+		{"no such host", &net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "testacct.snowflakecomputing.com", IsNotFound: true}}, 1},
+		{"unknown certificate authority", &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+			var calls atomic.Int32
+			client.httpClient = &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				calls.Add(1)
+				return nil, tt.err
+			})}
+			client.config.Retry = RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+			if _, _, err := client.Poll("test-handle", 0); err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := calls.Load(); got != tt.attempts {
+				t.Errorf("expected %d attempts, got %d", tt.attempts, got)
+			}
+		})
+	}
+}
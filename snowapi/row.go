@@ -0,0 +1,83 @@
+package snowapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoRows is returned when a single-row query produces no rows.
+var ErrNoRows = errors.New("snowapi: no rows in result set")
+
+// Row is a single result row with its column metadata.
+type Row struct {
+	values  []any
+	columns []ColumnMeta
+}
+
+// Scan copies the row into dest, one pointer per column, converting values
+// using the column metadata (e.g. into *int, *string or *time.Time).
+func (r Row) Scan(dest ...any) error {
+	return scanRow(r.values, r.columns, dest)
+}
+
+// Values returns the raw values of the row.
+func (r Row) Values() []any {
+	return r.values
+}
+
+// QueryRow executes a statement expected to return exactly one row. It
+// returns ErrNoRows for an empty result and an error for more than one row.
+func (c *Client) QueryRow(statement string) (Row, error) {
+	return c.QueryRowContext(context.Background(), statement)
+}
+
+// QueryRowContext is like QueryRow but honors ctx.
+func (c *Client) QueryRowContext(ctx context.Context, statement string) (Row, error) {
+	resp, err := c.queryCompleted(ctx, statement)
+	if err != nil {
+		return Row{}, err
+	}
+	if len(resp.Data) > 1 {
+		return Row{}, fmt.Errorf("expected a single row, got %d", len(resp.Data))
+	}
+	return firstRow(resp)
+}
+
+// QueryFirstRow is like QueryRow but ignores any rows after the first.
+func (c *Client) QueryFirstRow(statement string) (Row, error) {
+	return c.QueryFirstRowContext(context.Background(), statement)
+}
+
+// QueryFirstRowContext is like QueryFirstRow but honors ctx.
+func (c *Client) QueryFirstRowContext(ctx context.Context, statement string) (Row, error) {
+	resp, err := c.queryCompleted(ctx, statement)
+	if err != nil {
+		return Row{}, err
+	}
+	return firstRow(resp)
+}
+
+// queryCompleted executes statement synchronously and waits for it to
+// finish if it outlives the sync window.
+func (c *Client) queryCompleted(ctx context.Context, statement string) (*QueryResponse, error) {
+	opts := &RequestOptions{RequestID: uuid.New().String()}
+	body, err := c.newQueryRequest(statement, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.submit(ctx, body, false, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.awaitCompletion(ctx, resp, body)
+}
+
+func firstRow(resp *QueryResponse) (Row, error) {
+	if len(resp.Data) == 0 {
+		return Row{}, ErrNoRows
+	}
+	return Row{values: resp.Data[0], columns: resp.ResultSetMetaData.RowType}, nil
+}
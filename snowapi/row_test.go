@@ -0,0 +1,61 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestQueryRow(t *testing.T) {
+	body := `{"resultSetMetaData":{"rowType":[{"name":"COUNT","type":"fixed","scale":0},{"name":"NOW","type":"timestamp_ltz"},{"name":"WHO","type":"text"}]},"data":[["42","1710498645.000000000","me"]]}`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+
+	row, err := client.QueryRow("SELECT COUNT(*), CURRENT_TIMESTAMP(), CURRENT_USER()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var (
+		count int
+		now   time.Time
+		who   string
+	)
+	if err := row.Scan(&count, &now, &who); err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	if count != 42 || now.Unix() != 1710498645 || who != "me" {
+		t.Errorf("unexpected values: %d %v %s", count, now, who)
+	}
+
+	if err := row.Scan(&count); err == nil {
+		t.Error("expected error for wrong destination count")
+	}
+}
+
+func TestQueryRow_ResultShape(t *testing.T) {
+	data := `[]`
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"resultSetMetaData":{"rowType":[{"name":"N","type":"fixed"}]},"data":` + data + `}`))
+	})
+
+	if _, err := client.QueryRow("SELECT 1 WHERE FALSE"); !errors.Is(err, ErrNoRows) {
+		t.Errorf("expected ErrNoRows, got: %v", err)
+	}
+
+	data = `[["1"],["2"]]`
+	if _, err := client.QueryRow("SELECT n FROM t"); err == nil {
+		t.Error("expected error for multiple rows")
+	}
+
+	row, err := client.QueryFirstRow("SELECT n FROM t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var n int64
+	if err := row.Scan(&n); err != nil || n != 1 {
+		t.Errorf("expected first row 1, got %d (err %v)", n, err)
+	}
+}
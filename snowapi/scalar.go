@@ -0,0 +1,60 @@
+package snowapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNullValue is returned by the scalar accessors when the value is NULL.
+var ErrNullValue = errors.New("snowapi: value is NULL")
+
+// Int64 returns the result's single value, e.g. of SELECT COUNT(*).
+func (r *QueryResponse) Int64() (int64, error) {
+	var v int64
+	err := r.scalar(&v)
+	return v, err
+}
+
+// Float64 returns the result's single value as a float64.
+func (r *QueryResponse) Float64() (float64, error) {
+	var v float64
+	err := r.scalar(&v)
+	return v, err
+}
+
+// String returns the result's single value as its raw string.
+func (r *QueryResponse) String() (string, error) {
+	var v string
+	err := r.scalar(&v)
+	return v, err
+}
+
+// Bool returns the result's single BOOLEAN value.
+func (r *QueryResponse) Bool() (bool, error) {
+	var v bool
+	err := r.scalar(&v)
+	return v, err
+}
+
+// Time returns the result's single DATE, TIME or TIMESTAMP value.
+func (r *QueryResponse) Time() (time.Time, error) {
+	var v time.Time
+	err := r.scalar(&v)
+	return v, err
+}
+
+// scalar scans a result of exactly one row and one column into dest. It
+// returns ErrNoRows for an empty result and ErrNullValue for NULL.
+func (r *QueryResponse) scalar(dest any) error {
+	if len(r.Data) == 0 {
+		return ErrNoRows
+	}
+	if len(r.Data) > 1 || len(r.Data[0]) != 1 {
+		return fmt.Errorf("expected a single value, got %d rows of %d columns", len(r.Data), len(r.Data[0]))
+	}
+	if r.Data[0][0] == nil {
+		return ErrNullValue
+	}
+	return scanRow(r.Data[0], r.ResultSetMetaData.RowType, []any{dest})
+}
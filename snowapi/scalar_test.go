@@ -0,0 +1,54 @@
+package snowapi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scalarResponse(typ string, scale int, values ...any) *QueryResponse {
+	resp := &QueryResponse{ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{{Name: "V", Type: typ, Scale: &scale}}}}
+	for _, v := range values {
+		resp.Data = append(resp.Data, []any{v})
+	}
+	return resp
+}
+
+func TestQueryResponse_ScalarAccessors(t *testing.T) {
+	if n, err := scalarResponse("fixed", 0, "42").Int64(); err != nil || n != 42 {
+		t.Errorf("Int64: got %d, %v", n, err)
+	}
+	if f, err := scalarResponse("fixed", 2, "1.25").Float64(); err != nil || f != 1.25 {
+		t.Errorf("Float64: got %v, %v", f, err)
+	}
+	if s, err := scalarResponse("text", 0, "hello").String(); err != nil || s != "hello" {
+		t.Errorf("String: got %q, %v", s, err)
+	}
+	if b, err := scalarResponse("boolean", 0, "true").Bool(); err != nil || !b {
+		t.Errorf("Bool: got %v, %v", b, err)
+	}
+	want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	if tm, err := scalarResponse("date", 0, "19724").Time(); err != nil || !tm.Equal(want) {
+		t.Errorf("Time: got %v, %v", tm, err)
+	}
+}
+
+func TestQueryResponse_ScalarErrors(t *testing.T) {
+	if _, err := scalarResponse("fixed", 0).Int64(); !errors.Is(err, ErrNoRows) {
+		t.Errorf("expected ErrNoRows, got %v", err)
+	}
+	if _, err := scalarResponse("fixed", 0, nil).Int64(); !errors.Is(err, ErrNullValue) {
+		t.Errorf("expected ErrNullValue, got %v", err)
+	}
+	if _, err := scalarResponse("fixed", 0, "1", "2").Int64(); err == nil || !strings.Contains(err.Error(), "2 rows") {
+		t.Errorf("expected shape error for two rows, got %v", err)
+	}
+	wide := &QueryResponse{Data: [][]any{{"1", "2"}}}
+	if _, err := wide.Int64(); err == nil || !strings.Contains(err.Error(), "2 columns") {
+		t.Errorf("expected shape error for two columns, got %v", err)
+	}
+	if _, err := scalarResponse("text", 0, "abc").Bool(); err == nil {
+		t.Error("expected conversion error")
+	}
+}
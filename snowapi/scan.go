@@ -0,0 +1,347 @@
+package snowapi
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// ScanInto maps result rows onto dest, which must be a pointer to a slice of
+// structs (or struct pointers). Columns are matched to fields by a
+// `snow:"COL_NAME"` tag, falling back to a case-insensitive field name match.
+// Use pointer fields for nullable columns; NULL into a non-pointer field
+// leaves its zero value. VARIANT, OBJECT and ARRAY columns are JSON-decoded
+// into struct, map, slice or interface fields; string and json.RawMessage
+// fields receive the raw JSON.
+func (r *QueryResponse) ScanInto(dest interface{}) error {
+	sliceVal := reflect.ValueOf(dest)
+	if sliceVal.Kind() != reflect.Ptr || sliceVal.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("scan destination must be a pointer to a slice, got %T", dest)
+	}
+	sliceVal = sliceVal.Elem()
+
+	elemType := sliceVal.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("scan destination element must be a struct, got %s", elemType)
+	}
+
+	cols := r.ResultSetMetaData.RowType
+	fields := fieldIndexes(structType, cols)
+
+	out := reflect.MakeSlice(sliceVal.Type(), 0, len(r.Data))
+	for rowIdx, row := range r.Data {
+		elem := reflect.New(structType).Elem()
+		for colIdx, fieldIdx := range fields {
+			if fieldIdx == nil || colIdx >= len(row) {
+				continue
+			}
+			field := elem.FieldByIndex(fieldIdx)
+			if err := assignValue(field, row[colIdx], cols[colIdx]); err != nil {
+				return fmt.Errorf("row %d, column %s: %w", rowIdx, cols[colIdx].Name, err)
+			}
+		}
+		if elemType.Kind() == reflect.Ptr {
+			elem = elem.Addr()
+		}
+		out = reflect.Append(out, elem)
+	}
+
+	sliceVal.Set(out)
+	return nil
+}
+
+// fieldIndexes resolves, for each column, the index path of its struct field
+// (nil when no field matches).
+func fieldIndexes(t reflect.Type, cols []ColumnMeta) [][]int {
+	byTag := map[string][]int{}
+	byName := map[string][]int{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		tag := f.Tag.Get("snow")
+		if tag == "-" {
+			continue
+		}
+		if tag != "" {
+			byTag[tag] = f.Index
+		}
+		byName[strings.ToLower(f.Name)] = f.Index
+	}
+
+	indexes := make([][]int, len(cols))
+	for i, col := range cols {
+		if idx, ok := byTag[col.Name]; ok {
+			indexes[i] = idx
+		} else if idx, ok := byName[strings.ToLower(col.Name)]; ok {
+			indexes[i] = idx
+		}
+	}
+	return indexes
+}
+
+// assignValue decodes raw according to col and stores it in field.
+func assignValue(field reflect.Value, raw any, col ColumnMeta) error {
+	if raw == nil {
+		field.Set(reflect.Zero(field.Type()))
+		return nil
+	}
+
+	target := field
+	if field.Kind() == reflect.Ptr {
+		target = reflect.New(field.Type().Elem()).Elem()
+	}
+
+	if target.Type() == decimalType {
+		d, err := ScanDecimal(raw, col)
+		if err != nil {
+			return err
+		}
+		target.Set(reflect.ValueOf(d))
+		setTarget(field, target)
+		return nil
+	}
+
+	// Strings receive the raw value untouched.
+	if target.Kind() == reflect.String {
+		s, ok := raw.(string)
+		if !ok {
+			s = fmt.Sprint(raw)
+		}
+		target.SetString(s)
+		setTarget(field, target)
+		return nil
+	}
+
+	if isSemiStructured(col) {
+		data := semiStructuredBytes(raw)
+		if target.Type() == rawMessageType {
+			target.SetBytes(data)
+		} else if err := json.Unmarshal(data, target.Addr().Interface()); err != nil {
+			return fmt.Errorf("cannot decode %s column into %s: %w", col.Type, field.Type(), err)
+		}
+		setTarget(field, target)
+		return nil
+	}
+
+	val, err := DecodeValue(raw, col)
+	if err != nil {
+		return err
+	}
+
+	switch v := val.(type) {
+	case int64:
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if target.OverflowInt(v) {
+				return fmt.Errorf("value %d overflows %s", v, target.Type())
+			}
+			target.SetInt(v)
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(float64(v))
+		default:
+			return incompatible(col, field)
+		}
+	case float64:
+		switch target.Kind() {
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(v)
+		default:
+			return incompatible(col, field)
+		}
+	case Decimal:
+		switch target.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n, ok := v.Int64()
+			if !ok || target.OverflowInt(n) {
+				return fmt.Errorf("value %s overflows %s", v, target.Type())
+			}
+			target.SetInt(n)
+		case reflect.Float32, reflect.Float64:
+			target.SetFloat(v.Float64())
+		default:
+			return incompatible(col, field)
+		}
+	case bool:
+		if target.Kind() != reflect.Bool {
+			return incompatible(col, field)
+		}
+		target.SetBool(v)
+	case time.Time:
+		if target.Type() != timeType {
+			return incompatible(col, field)
+		}
+		target.Set(reflect.ValueOf(v))
+	case []byte:
+		if target.Kind() != reflect.Slice || target.Type().Elem().Kind() != reflect.Uint8 {
+			return incompatible(col, field)
+		}
+		target.SetBytes(v)
+	default:
+		return incompatible(col, field)
+	}
+
+	setTarget(field, target)
+	return nil
+}
+
+// setTarget stores target into field, allocating when field is a pointer.
+func setTarget(field, target reflect.Value) {
+	if field.Kind() == reflect.Ptr {
+		ptr := reflect.New(target.Type())
+		ptr.Elem().Set(target)
+		field.Set(ptr)
+	}
+}
+
+func incompatible(col ColumnMeta, field reflect.Value) error {
+	return fmt.Errorf("cannot scan %s column into field of type %s", col.Type, field.Type())
+}
+
+// DecodeValue converts a result value into its native Go type based on
+// col.Type: FIXED with scale 0 to int64 (or Decimal when the value does
+// not fit) and with a scale to float64 (or Decimal when the precision
+// exceeds what float64 holds exactly), REAL to float64, BOOLEAN to bool,
+// DATE and TIME/TIMESTAMP_* (epoch encoded) to time.Time, BINARY (hex) to
+// []byte, and VARIANT, OBJECT and ARRAY (JSON) to map[string]any, []any or
+// a JSON scalar, with numbers as json.Number so large integers stay exact;
+// other types are returned as strings. Values may be strings (json format)
+// or native JSON numbers (json.Number or float64) and booleans (jsonv2).
+// NULL decodes to nil.
+func DecodeValue(raw any, col ColumnMeta) (any, error) {
+	var s string
+	switch v := raw.(type) {
+	case string:
+		s = v
+	case json.Number:
+		s = v.String()
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		s = strconv.FormatBool(v)
+	default:
+		return raw, nil
+	}
+
+	switch strings.ToLower(col.Type) {
+	case "fixed", "number":
+		if wantsDecimal(col) {
+			return ScanDecimal(s, col)
+		}
+		if col.Scale == nil || *col.Scale == 0 {
+			n, err := strconv.ParseInt(s, 10, 64)
+			if errors.Is(err, strconv.ErrRange) {
+				return ScanDecimal(s, col)
+			}
+			return n, err
+		}
+		return strconv.ParseFloat(s, 64)
+	case "real":
+		return strconv.ParseFloat(s, 64)
+	case "boolean":
+		return strconv.ParseBool(s)
+	case "date":
+		days, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(days*86400, 0).UTC(), nil
+	case "time", "timestamp_ntz", "timestamp_ltz":
+		t, err := parseEpoch(s)
+		if err != nil {
+			return nil, err
+		}
+		return t.UTC(), nil
+	case "timestamp_tz":
+		// Encoded as "<epoch> <offset minutes + 1440>".
+		parts := strings.Fields(s)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("invalid timestamp_tz value %q", s)
+		}
+		t, err := parseEpoch(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(parts) < 2 {
+			return t.UTC(), nil
+		}
+		offset, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone offset %q", parts[1])
+		}
+		minutes := offset - 1440
+		return t.In(time.FixedZone("", minutes*60)), nil
+	case "binary":
+		return hex.DecodeString(s)
+	case "variant", "object", "array":
+		dec := json.NewDecoder(strings.NewReader(s))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", col.Type, err)
+		}
+		return v, nil
+	default:
+		return s, nil
+	}
+}
+
+// isSemiStructured reports whether col holds JSON text (VARIANT, OBJECT or
+// ARRAY).
+func isSemiStructured(col ColumnMeta) bool {
+	switch strings.ToLower(col.Type) {
+	case "variant", "object", "array":
+		return true
+	}
+	return false
+}
+
+// semiStructuredBytes returns the JSON text of a semi-structured value,
+// re-encoding values that were not delivered as strings.
+func semiStructuredBytes(raw any) []byte {
+	if s, ok := raw.(string); ok {
+		return []byte(s)
+	}
+	b, _ := json.Marshal(raw)
+	return b
+}
+
+// parseEpoch parses "seconds[.fraction]" without going through float64.
+func parseEpoch(s string) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid epoch value %q", s)
+	}
+
+	var nsec int64
+	if fracPart != "" {
+		if len(fracPart) > 9 {
+			fracPart = fracPart[:9]
+		}
+		fracPart += strings.Repeat("0", 9-len(fracPart))
+		nsec, err = strconv.ParseInt(fracPart, 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid epoch value %q", s)
+		}
+		if strings.HasPrefix(secPart, "-") {
+			nsec = -nsec
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
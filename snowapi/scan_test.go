@@ -0,0 +1,305 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func intPtr(i int) *int { return &i }
+
+func scanTestResponse() *QueryResponse {
+	return &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{
+			RowType: []ColumnMeta{
+				{Name: "ID", Type: "fixed", Scale: intPtr(0)},
+				{Name: "NAME", Type: "text"},
+				{Name: "PRICE", Type: "fixed", Scale: intPtr(2)},
+				{Name: "ACTIVE", Type: "boolean"},
+				{Name: "CREATED_AT", Type: "timestamp_ntz", Scale: intPtr(9)},
+				{Name: "NOTE", Type: "text", Nullable: true},
+			},
+		},
+		Data: [][]any{
+			{"1", "widget", "9.99", "true", "1710498645.123000000", "fragile"},
+			{"2", "gadget", "12.50", "false", "1710498645.000000000", nil},
+		},
+	}
+}
+
+func TestScanInto(t *testing.T) {
+	type product struct {
+		ID        int
+		Name      string
+		Price     float64
+		Active    bool
+		CreatedAt time.Time `snow:"CREATED_AT"`
+		Note      *string
+	}
+
+	var rows []product
+	if err := scanTestResponse().ScanInto(&rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+
+	first := rows[0]
+	if first.ID != 1 || first.Name != "widget" || first.Price != 9.99 || !first.Active {
+		t.Errorf("unexpected first row: %+v", first)
+	}
+	want := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)
+	if !first.CreatedAt.Equal(want) {
+		t.Errorf("expected %v, got %v", want, first.CreatedAt)
+	}
+	if first.Note == nil || *first.Note != "fragile" {
+		t.Errorf("expected note to be set, got %v", first.Note)
+	}
+	if rows[1].Note != nil {
+		t.Errorf("expected nil note for NULL, got %v", *rows[1].Note)
+	}
+}
+
+func TestScanInto_PointerElements(t *testing.T) {
+	type product struct {
+		ID *int `snow:"ID"`
+	}
+
+	var rows []*product
+	if err := scanTestResponse().ScanInto(&rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rows) != 2 || rows[1].ID == nil || *rows[1].ID != 2 {
+		t.Errorf("unexpected rows: %+v", rows)
+	}
+}
+
+func TestScanInto_IncompatibleType(t *testing.T) {
+	type product struct {
+		Active time.Time
+	}
+
+	var rows []product
+	err := scanTestResponse().ScanInto(&rows)
+	if err == nil || !strings.Contains(err.Error(), "cannot scan boolean column into field of type time.Time") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestDecodeValue(t *testing.T) {
+	scale2 := 2
+	tests := []struct {
+		raw  any
+		col  ColumnMeta
+		want any
+	}{
+		{"42", ColumnMeta{Type: "fixed"}, int64(42)},
+		{"19.99", ColumnMeta{Type: "fixed", Scale: &scale2}, 19.99},
+		{"0.5", ColumnMeta{Type: "real"}, 0.5},
+		{"true", ColumnMeta{Type: "boolean"}, true},
+		{"19797", ColumnMeta{Type: "date"}, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"1710498645.123000000", ColumnMeta{Type: "timestamp_ntz"}, time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC)},
+		{"cafe", ColumnMeta{Type: "binary"}, []byte{0xca, 0xfe}},
+		{"hello", ColumnMeta{Type: "text"}, "hello"},
+		{`{"a":{"b":[1,2]}}`, ColumnMeta{Type: "object"}, map[string]any{"a": map[string]any{"b": []any{json.Number("1"), json.Number("2")}}}},
+		{`[1,"x",null]`, ColumnMeta{Type: "array"}, []any{json.Number("1"), "x", nil}},
+		{`"just a string"`, ColumnMeta{Type: "variant"}, "just a string"},
+		{nil, ColumnMeta{Type: "fixed"}, nil},
+	}
+	for _, tt := range tests {
+		got, err := DecodeValue(tt.raw, tt.col)
+		if err != nil {
+			t.Errorf("%s %v: unexpected error: %v", tt.col.Type, tt.raw, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %v: expected %#v, got %#v", tt.col.Type, tt.raw, tt.want, got)
+		}
+	}
+}
+
+func TestDecodeValue_TimestampTZ(t *testing.T) {
+	v, err := DecodeValue("1710498645.000000000 1800", ColumnMeta{Type: "timestamp_tz"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ts := v.(time.Time)
+	if _, offset := ts.Zone(); offset != 6*3600 {
+		t.Errorf("expected +06:00 offset, got %d", offset)
+	}
+	if ts.Unix() != 1710498645 {
+		t.Errorf("unexpected epoch: %d", ts.Unix())
+	}
+}
+
+// jsonv2Sample is a captured jsonv2 payload with native numbers and booleans.
+const jsonv2Sample = `{
+  "resultSetMetaData": {
+    "numRows": 1,
+    "format": "jsonv2",
+    "rowType": [
+      {"name": "ID", "type": "fixed", "scale": 0, "precision": 38, "nullable": false},
+      {"name": "AMOUNT", "type": "fixed", "scale": 2, "precision": 10, "nullable": true},
+      {"name": "RATIO", "type": "real", "nullable": true},
+      {"name": "ACTIVE", "type": "boolean", "nullable": true},
+      {"name": "CREATED_AT", "type": "timestamp_ntz", "scale": 9, "nullable": true}
+    ]
+  },
+  "data": [[42, 19.99, 0.5, true, "1710498645.123000000"]],
+  "code": "090001",
+  "statementHandle": "01b2c3d4-0000-1111-0000-000000000001"
+}`
+
+func TestScanInto_JSONv2(t *testing.T) {
+	var resp QueryResponse
+	if err := json.Unmarshal([]byte(jsonv2Sample), &resp); err != nil {
+		t.Fatalf("failed to decode sample: %v", err)
+	}
+
+	type row struct {
+		ID        int64
+		Amount    float64
+		Ratio     float64
+		Active    bool
+		CreatedAt time.Time `snow:"CREATED_AT"`
+	}
+	var rows []row
+	if err := resp.ScanInto(&rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := rows[0]
+	if got.ID != 42 || got.Amount != 19.99 || got.Ratio != 0.5 || !got.Active {
+		t.Errorf("unexpected row: %+v", got)
+	}
+	if want := time.Date(2024, 3, 15, 10, 30, 45, 123000000, time.UTC); !got.CreatedAt.Equal(want) {
+		t.Errorf("expected %v, got %v", want, got.CreatedAt)
+	}
+
+	// ID is NUMBER(38,0); values that fit stay int64.
+	id, err := DecodeValue(resp.Data[0][0], resp.ResultSetMetaData.RowType[0])
+	if id != int64(42) || err != nil {
+		t.Errorf("expected int64 42 for NUMBER(38,0), got %T %v (err %v)", id, id, err)
+	}
+}
+
+func TestDecodeValue_PreservesLargeIntegers(t *testing.T) {
+	const big = "1234567890123456789" // 19 digits, not exact as a float64
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"code":"090001","resultSetMetaData":{"format":"jsonv2","rowType":[` +
+			`{"name":"ID","type":"fixed","scale":0},{"name":"V","type":"variant"}]},` +
+			`"data":[[` + big + `,"{\"id\":` + big + `}"]]}`))
+	})
+
+	resp, err := client.Execute("SELECT id, v FROM t", false, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if raw, ok := resp.Data[0][0].(json.Number); !ok || raw.String() != big {
+		t.Fatalf("expected the raw value as json.Number %s, got %T %v", big, resp.Data[0][0], resp.Data[0][0])
+	}
+
+	records, err := resp.ToRecords()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id := records[0]["ID"]; id != int64(1234567890123456789) {
+		t.Errorf("expected exact int64, got %T %v", id, id)
+	}
+	nested, _ := records[0]["V"].(map[string]any)
+	if n, ok := nested["id"].(json.Number); !ok || n.String() != big {
+		t.Errorf("expected nested json.Number %s, got %T %v", big, nested["id"], nested["id"])
+	}
+}
+
+func TestNewQueryRequest_ResultFormat(t *testing.T) {
+	client := &Client{config: Config{ResultFormat: FormatJSONv2}}
+
+	body, err := client.newQueryRequest("SELECT 1", nil)
+	if err != nil || body.ResultSetMetaData.Format != "jsonv2" {
+		t.Errorf("expected jsonv2 from config, got %+v (err %v)", body.ResultSetMetaData, err)
+	}
+
+	body, err = client.newQueryRequest("SELECT 1", &RequestOptions{ResultFormat: FormatJSON})
+	if err != nil || body.ResultSetMetaData.Format != "json" {
+		t.Errorf("expected per-request json override, got %+v (err %v)", body.ResultSetMetaData, err)
+	}
+
+	if _, err := client.newQueryRequest("SELECT 1", &RequestOptions{ResultFormat: "xml"}); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestScanInto_SemiStructured(t *testing.T) {
+	type address struct {
+		City string   `json:"city"`
+		Tags []string `json:"tags"`
+	}
+	type record struct {
+		Address address         `snow:"ADDRESS"`
+		Attrs   map[string]any  `snow:"ATTRS"`
+		Items   []any           `snow:"ITEMS"`
+		Raw     json.RawMessage `snow:"RAW"`
+		Text    string          `snow:"TEXT"`
+		Missing *address        `snow:"MISSING"`
+		Nested  *address        `snow:"NESTED"`
+	}
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{
+			{Name: "ADDRESS", Type: "variant"},
+			{Name: "ATTRS", Type: "object"},
+			{Name: "ITEMS", Type: "array"},
+			{Name: "RAW", Type: "variant"},
+			{Name: "TEXT", Type: "variant"},
+			{Name: "MISSING", Type: "variant"},
+			{Name: "NESTED", Type: "object"},
+		}},
+		Data: [][]any{{
+			`{"city":"Oslo","tags":["a","b"]}`,
+			`{"k":{"deep":true}}`,
+			`[1,[2,3]]`,
+			`{"x": 1}`,
+			`{"y": 2}`,
+			nil,
+			`{"city":"Bergen"}`,
+		}},
+	}
+
+	var out []record
+	if err := resp.ScanInto(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := out[0]
+	if r.Address.City != "Oslo" || !reflect.DeepEqual(r.Address.Tags, []string{"a", "b"}) {
+		t.Errorf("unexpected struct field: %+v", r.Address)
+	}
+	if !reflect.DeepEqual(r.Attrs, map[string]any{"k": map[string]any{"deep": true}}) {
+		t.Errorf("unexpected map field: %#v", r.Attrs)
+	}
+	if !reflect.DeepEqual(r.Items, []any{1.0, []any{2.0, 3.0}}) {
+		t.Errorf("unexpected slice field: %#v", r.Items)
+	}
+	if string(r.Raw) != `{"x": 1}` || r.Text != `{"y": 2}` {
+		t.Errorf("expected raw JSON to pass through, got %s and %q", r.Raw, r.Text)
+	}
+	if r.Missing != nil || r.Nested == nil || r.Nested.City != "Bergen" {
+		t.Errorf("unexpected pointer fields: %v, %+v", r.Missing, r.Nested)
+	}
+}
+
+func TestScanInto_InvalidSemiStructured(t *testing.T) {
+	resp := &QueryResponse{
+		ResultSetMetaData: ResultSetMetaData{RowType: []ColumnMeta{{Name: "V", Type: "variant"}}},
+		Data:              [][]any{{`{not json`}},
+	}
+	var out []struct {
+		V map[string]any `snow:"V"`
+	}
+	if err := resp.ScanInto(&out); err == nil || !strings.Contains(err.Error(), "cannot decode variant") {
+		t.Errorf("expected decode error, got %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package snowapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ColumnSpec is an expected result column. Name must match exactly; Type is
+// compared case-insensitively against ColumnMeta.Type (e.g. "fixed", "text")
+// and matches any type when empty.
+type ColumnSpec struct {
+	Name string
+	Type string
+}
+
+// SchemaMismatchError is returned by ExpectColumns and ExpectColumnsAnyOrder
+// with one entry per difference found.
+type SchemaMismatchError struct {
+	Mismatches []string
+}
+
+func (e *SchemaMismatchError) Error() string {
+	return "result schema mismatch: " + strings.Join(e.Mismatches, "; ")
+}
+
+// ExpectColumns checks that the result has exactly the expected columns, in
+// order, and returns a *SchemaMismatchError describing each missing,
+// unexpected, reordered or retyped column otherwise.
+func (r *QueryResponse) ExpectColumns(expected []ColumnSpec) error {
+	cols := r.Columns()
+	positions := columnPositions(cols)
+
+	var mismatches []string
+	for i, spec := range expected {
+		if i >= len(cols) {
+			mismatches = append(mismatches, fmt.Sprintf("missing column %s at position %d", spec.Name, i+1))
+			continue
+		}
+		col := cols[i]
+		if col.Name != spec.Name {
+			m := fmt.Sprintf("column %d: expected %s, got %s", i+1, spec.Name, col.Name)
+			if j, ok := positions[spec.Name]; ok {
+				m += fmt.Sprintf(" (%s is column %d)", spec.Name, j+1)
+			}
+			mismatches = append(mismatches, m)
+			continue
+		}
+		if m, ok := typeMismatch(spec, col); ok {
+			mismatches = append(mismatches, m)
+		}
+	}
+	for i := len(expected); i < len(cols); i++ {
+		mismatches = append(mismatches, fmt.Sprintf("unexpected column %s at position %d", cols[i].Name, i+1))
+	}
+	return schemaMismatch(mismatches)
+}
+
+// ExpectColumnsAnyOrder is like ExpectColumns but matches columns by name,
+// ignoring their order.
+func (r *QueryResponse) ExpectColumnsAnyOrder(expected []ColumnSpec) error {
+	cols := r.Columns()
+	positions := columnPositions(cols)
+
+	var mismatches []string
+	want := make(map[string]bool, len(expected))
+	for _, spec := range expected {
+		want[spec.Name] = true
+		i, ok := positions[spec.Name]
+		if !ok {
+			mismatches = append(mismatches, "missing column "+spec.Name)
+			continue
+		}
+		if m, ok := typeMismatch(spec, cols[i]); ok {
+			mismatches = append(mismatches, m)
+		}
+	}
+	for _, col := range cols {
+		if !want[col.Name] {
+			mismatches = append(mismatches, "unexpected column "+col.Name)
+		}
+	}
+	return schemaMismatch(mismatches)
+}
+
+// columnPositions maps each column name to its first index.
+func columnPositions(cols []ColumnMeta) map[string]int {
+	positions := make(map[string]int, len(cols))
+	for i, col := range cols {
+		if _, ok := positions[col.Name]; !ok {
+			positions[col.Name] = i
+		}
+	}
+	return positions
+}
+
+func typeMismatch(spec ColumnSpec, col ColumnMeta) (string, bool) {
+	if spec.Type == "" || strings.EqualFold(spec.Type, col.Type) {
+		return "", false
+	}
+	return fmt.Sprintf("column %s: expected type %s, got %s", col.Name, spec.Type, col.Type), true
+}
+
+func schemaMismatch(mismatches []string) error {
+	if len(mismatches) == 0 {
+		return nil
+	}
+	return &SchemaMismatchError{Mismatches: mismatches}
+}
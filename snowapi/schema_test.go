@@ -0,0 +1,101 @@
+package snowapi
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func schemaResponse(cols ...ColumnMeta) *QueryResponse {
+	return &QueryResponse{ResultSetMetaData: ResultSetMetaData{RowType: cols}}
+}
+
+func TestExpectColumns(t *testing.T) {
+	expected := []ColumnSpec{{Name: "ID", Type: "FIXED"}, {Name: "NAME", Type: "text"}, {Name: "CREATED_AT"}}
+
+	tests := []struct {
+		name string
+		cols []ColumnMeta
+		want []string
+	}{
+		{
+			"match",
+			[]ColumnMeta{{Name: "ID", Type: "fixed"}, {Name: "NAME", Type: "text"}, {Name: "CREATED_AT", Type: "timestamp_ntz"}},
+			nil,
+		},
+		{
+			"reordered",
+			[]ColumnMeta{{Name: "NAME", Type: "text"}, {Name: "ID", Type: "fixed"}, {Name: "CREATED_AT", Type: "timestamp_ntz"}},
+			[]string{"column 1: expected ID, got NAME (ID is column 2)", "column 2: expected NAME, got ID (NAME is column 1)"},
+		},
+		{
+			"renamed",
+			[]ColumnMeta{{Name: "ID", Type: "fixed"}, {Name: "FULL_NAME", Type: "text"}, {Name: "CREATED_AT", Type: "timestamp_ntz"}},
+			[]string{"column 2: expected NAME, got FULL_NAME"},
+		},
+		{
+			"type changed",
+			[]ColumnMeta{{Name: "ID", Type: "text"}, {Name: "NAME", Type: "text"}, {Name: "CREATED_AT", Type: "date"}},
+			[]string{"column ID: expected type FIXED, got text"},
+		},
+		{
+			"missing",
+			[]ColumnMeta{{Name: "ID", Type: "fixed"}, {Name: "NAME", Type: "text"}},
+			[]string{"missing column CREATED_AT at position 3"},
+		},
+		{
+			"extra",
+			[]ColumnMeta{{Name: "ID", Type: "fixed"}, {Name: "NAME", Type: "text"}, {Name: "CREATED_AT", Type: "date"}, {Name: "EMAIL", Type: "text"}},
+			[]string{"unexpected column EMAIL at position 4"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertMismatches(t, schemaResponse(tt.cols...).ExpectColumns(expected), tt.want)
+		})
+	}
+}
+
+func TestExpectColumnsAnyOrder(t *testing.T) {
+	expected := []ColumnSpec{{Name: "ID", Type: "fixed"}, {Name: "NAME", Type: "text"}}
+
+	tests := []struct {
+		name string
+		cols []ColumnMeta
+		want []string
+	}{
+		{"reordered", []ColumnMeta{{Name: "NAME", Type: "text"}, {Name: "ID", Type: "fixed"}}, nil},
+		{
+			"renamed",
+			[]ColumnMeta{{Name: "FULL_NAME", Type: "text"}, {Name: "ID", Type: "fixed"}},
+			[]string{"missing column NAME", "unexpected column FULL_NAME"},
+		},
+		{
+			"type changed",
+			[]ColumnMeta{{Name: "NAME", Type: "text"}, {Name: "ID", Type: "real"}},
+			[]string{"column ID: expected type fixed, got real"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertMismatches(t, schemaResponse(tt.cols...).ExpectColumnsAnyOrder(expected), tt.want)
+		})
+	}
+}
+
+func assertMismatches(t *testing.T, err error, want []string) {
+	t.Helper()
+	if want == nil {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	var mismatch *SchemaMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected a *SchemaMismatchError, got %v", err)
+	}
+	if !reflect.DeepEqual(mismatch.Mismatches, want) {
+		t.Errorf("mismatches = %q, want %q", mismatch.Mismatches, want)
+	}
+}
@@ -0,0 +1,164 @@
+// Package snowapitest provides a fake snowapi.SnowClient for testing code
+// that depends on the Snowflake SQL API client.
+package snowapitest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+// FakeClient is an in-memory snowapi.SnowClient. Statements are answered
+// from Results and Errors (keyed by the exact statement text), or by
+// ExecuteFunc when set. Every call is recorded for assertions. The zero
+// value is ready to use; configure it before sharing across goroutines.
+type FakeClient struct {
+	// Results maps a statement to its canned response.
+	Results map[string]*snowapi.QueryResponse
+	// Errors maps a statement to the error Execute returns for it.
+	Errors map[string]error
+	// ExecuteFunc, when set, answers every statement instead of the maps.
+	ExecuteFunc func(statement string, async bool, opts *snowapi.RequestOptions) (*snowapi.QueryResponse, error)
+
+	mu         sync.Mutex
+	statements []string
+	cancelled  []string
+	byHandle   map[string]*snowapi.QueryResponse
+	nextHandle int
+}
+
+var _ snowapi.SnowClient = (*FakeClient)(nil)
+
+// Statements returns the statements executed so far, in order.
+func (f *FakeClient) Statements() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.statements...)
+}
+
+// Cancelled returns the handles passed to Cancel, in order.
+func (f *FakeClient) Cancelled() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.cancelled...)
+}
+
+// Query executes statement and returns its rows.
+func (f *FakeClient) Query(statement string) ([][]any, error) {
+	return f.QueryContext(context.Background(), statement)
+}
+
+// QueryContext is like Query; ctx is only checked for cancellation.
+func (f *FakeClient) QueryContext(ctx context.Context, statement string) ([][]any, error) {
+	resp, err := f.ExecuteContext(ctx, statement, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Execute records statement and returns its canned response.
+func (f *FakeClient) Execute(statement string, async bool, opts *snowapi.RequestOptions) (*snowapi.QueryResponse, error) {
+	return f.ExecuteContext(context.Background(), statement, async, opts)
+}
+
+// ExecuteContext is like Execute; ctx is only checked for cancellation.
+func (f *FakeClient) ExecuteContext(ctx context.Context, statement string, async bool, opts *snowapi.RequestOptions) (*snowapi.QueryResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	f.statements = append(f.statements, statement)
+	execute := f.ExecuteFunc
+	f.mu.Unlock()
+
+	var resp *snowapi.QueryResponse
+	if execute != nil {
+		var err error
+		if resp, err = execute(statement, async, opts); err != nil {
+			return nil, err
+		}
+	} else {
+		if err, ok := f.Errors[statement]; ok {
+			return nil, err
+		}
+		var ok bool
+		if resp, ok = f.Results[statement]; !ok {
+			return nil, fmt.Errorf("snowapitest: no result configured for statement %q", statement)
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if resp.StatementHandle == "" {
+		f.nextHandle++
+		copied := *resp
+		copied.StatementHandle = fmt.Sprintf("fake-handle-%d", f.nextHandle)
+		resp = &copied
+	}
+	if f.byHandle == nil {
+		f.byHandle = make(map[string]*snowapi.QueryResponse)
+	}
+	f.byHandle[resp.StatementHandle] = resp
+	return resp, nil
+}
+
+// Poll returns the response of a previously executed statement with status
+// 200, or 404 for an unknown handle.
+func (f *FakeClient) Poll(handle string, partition int) (*snowapi.QueryResponse, int, error) {
+	return f.PollContext(context.Background(), handle, partition)
+}
+
+// PollContext is like Poll; ctx is only checked for cancellation.
+func (f *FakeClient) PollContext(ctx context.Context, handle string, partition int) (*snowapi.QueryResponse, int, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	resp, ok := f.byHandle[handle]
+	if !ok {
+		return &snowapi.QueryResponse{Code: "000709", Message: "Statement not found"}, http.StatusNotFound, nil
+	}
+	return resp, http.StatusOK, nil
+}
+
+// Cancel records handle.
+func (f *FakeClient) Cancel(statementHandle string) error {
+	return f.CancelContext(context.Background(), statementHandle)
+}
+
+// CancelContext is like Cancel; ctx is only checked for cancellation.
+func (f *FakeClient) CancelContext(ctx context.Context, statementHandle string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = append(f.cancelled, statementHandle)
+	return nil
+}
+
+// WaitUntilComplete returns the response of a previously executed statement
+// immediately, or an *snowapi.APIError for an unknown handle.
+func (f *FakeClient) WaitUntilComplete(handle string, interval time.Duration, maxRetries int) (*snowapi.QueryResponse, error) {
+	return f.WaitUntilCompleteContext(context.Background(), handle, interval, maxRetries)
+}
+
+// WaitUntilCompleteContext is like WaitUntilComplete; ctx is only checked
+// for cancellation.
+func (f *FakeClient) WaitUntilCompleteContext(ctx context.Context, handle string, interval time.Duration, maxRetries int) (*snowapi.QueryResponse, error) {
+	resp, status, err := f.PollContext(ctx, handle, 0)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, &snowapi.APIError{Code: resp.Code, Message: resp.Message, StatementHandle: handle, HTTPStatus: status}
+	}
+	return resp, nil
+}
@@ -0,0 +1,60 @@
+package snowapitest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+// countUsers is an example consumer that depends on the interface.
+func countUsers(c snowapi.SnowClient) (int, error) {
+	rows, err := c.Query("SELECT COUNT(*) FROM users")
+	if err != nil {
+		return 0, err
+	}
+	return len(rows), nil
+}
+
+func TestFakeClient(t *testing.T) {
+	boom := errors.New("boom")
+	fake := &FakeClient{
+		Results: map[string]*snowapi.QueryResponse{
+			"SELECT COUNT(*) FROM users": {Data: [][]any{{"42"}}},
+		},
+		Errors: map[string]error{"DROP TABLE users": boom},
+	}
+
+	if n, err := countUsers(fake); err != nil || n != 1 {
+		t.Errorf("unexpected result: %d (err %v)", n, err)
+	}
+	if _, err := fake.Execute("DROP TABLE users", false, nil); !errors.Is(err, boom) {
+		t.Errorf("expected configured error, got: %v", err)
+	}
+	if _, err := fake.Query("SELECT 1"); err == nil {
+		t.Error("expected error for unconfigured statement")
+	}
+
+	resp, err := fake.Execute("SELECT COUNT(*) FROM users", true, nil)
+	if err != nil || resp.StatementHandle == "" {
+		t.Fatalf("expected a generated handle, got %+v (err %v)", resp, err)
+	}
+	waited, err := fake.WaitUntilComplete(resp.StatementHandle, time.Second, 1)
+	if err != nil || waited.Data[0][0] != "42" {
+		t.Errorf("unexpected wait result: %+v (err %v)", waited, err)
+	}
+	if _, err := fake.WaitUntilComplete("missing", time.Second, 1); err == nil {
+		t.Error("expected error for unknown handle")
+	}
+
+	if err := fake.Cancel(resp.StatementHandle); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.Cancelled(); len(got) != 1 || got[0] != resp.StatementHandle {
+		t.Errorf("unexpected cancellations: %v", got)
+	}
+	if got := fake.Statements(); len(got) != 4 {
+		t.Errorf("expected 4 recorded statements, got %v", got)
+	}
+}
@@ -0,0 +1,150 @@
+// Package sqldriver exposes the Snowflake SQL API client through
+// database/sql:
+//
+//	import _ "github.com/vjain20/gosnowapi/snowapi/sqldriver"
+//
+//	db, err := sql.Open("gosnowapi", "user@account/db/schema?warehouse=WH&privateKeyPath=rsa_key.p8")
+//
+// Statements run through the SQL API, so transactions spanning multiple
+// calls are not supported. Placeholders are positional (?).
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+// DriverName is the name registered with database/sql.
+const DriverName = "gosnowapi"
+
+func init() {
+	sql.Register(DriverName, &Driver{})
+}
+
+// Driver implements driver.Driver on top of snowapi.Client.
+type Driver struct{}
+
+// Open parses dsn (see ParseDSN) and returns a connection.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	client, err := snowapi.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{client: client}, nil
+}
+
+// conn is a stateless handle around a client; every statement is its own
+// SQL API request.
+type conn struct {
+	client *snowapi.Client
+}
+
+var (
+	_ driver.QueryerContext = (*conn)(nil)
+	_ driver.ExecerContext  = (*conn)(nil)
+)
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error { return c.client.Close() }
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, errors.New("sqldriver: transactions are not supported")
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	resp, err := c.execute(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return newRows(c.client.NewRowIterator(ctx, resp)), nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	resp, err := c.execute(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	n, err := resp.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	return result{rowsAffected: n}, nil
+}
+
+// execute submits query with positional bindings and waits for completion.
+func (c *conn) execute(ctx context.Context, query string, args []driver.NamedValue) (*snowapi.QueryResponse, error) {
+	values := make([]any, len(args))
+	for _, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("sqldriver: named parameters are not supported (got %q)", arg.Name)
+		}
+		values[arg.Ordinal-1] = arg.Value
+	}
+
+	resp, err := c.client.ExecuteWithBindingsContext(ctx, query, values, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Still running after the sync window: poll as the client's own queries
+	// do, for up to the statement timeout.
+	return c.client.WaitForCompletion(ctx, resp, nil)
+}
+
+type stmt struct {
+	conn  *conn
+	query string
+}
+
+var (
+	_ driver.StmtQueryContext = (*stmt)(nil)
+	_ driver.StmtExecContext  = (*stmt)(nil)
+)
+
+func (s *stmt) Close() error  { return nil }
+func (s *stmt) NumInput() int { return -1 }
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.conn.ExecContext(ctx, s.query, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.conn.QueryContext(ctx, s.query, args)
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+type result struct {
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) {
+	return 0, errors.New("sqldriver: LastInsertId is not supported")
+}
+
+func (r result) RowsAffected() (int64, error) { return r.rowsAffected, nil }
@@ -0,0 +1,189 @@
+package sqldriver
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeKeyPair writes a PEM-encoded RSA key pair into dir.
+func writeKeyPair(t *testing.T, dir string) (privPath, pubPath string) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privPath = filepath.Join(dir, "rsa_key.p8")
+	pubPath = filepath.Join(dir, "rsa_key.pub")
+	if err := os.WriteFile(privPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return privPath, pubPath
+}
+
+func TestParseDSN(t *testing.T) {
+	privPath, pubPath := writeKeyPair(t, t.TempDir())
+	dsn := "jdoe@xy12345/SALES/PUBLIC?warehouse=WH&role=ANALYST&expireAfter=30m" +
+		"&privateKeyPath=" + url.QueryEscape(privPath) + "&publicKeyPath=" + url.QueryEscape(pubPath)
+
+	cfg, err := ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.User != "jdoe" || cfg.Account != "xy12345" || cfg.Database != "SALES" || cfg.Schema != "PUBLIC" {
+		t.Errorf("unexpected identity: %+v", cfg)
+	}
+	if cfg.Warehouse != "WH" || cfg.Role != "ANALYST" || cfg.ExpireAfter != 30*time.Minute {
+		t.Errorf("unexpected params: %+v", cfg)
+	}
+	if len(cfg.PrivateKey) == 0 || len(cfg.PublicKey) == 0 {
+		t.Error("expected key material to be loaded")
+	}
+
+	withoutPub, err := ParseDSN("jdoe@xy12345?privateKeyPath=" + url.QueryEscape(privPath))
+	if err != nil || len(withoutPub.PublicKey) != 0 {
+		t.Errorf("expected publicKeyPath to be optional (err %v)", err)
+	}
+
+	for _, bad := range []string{"xy12345/db", "jdoe@xy12345/a/b/c?privateKeyPath=x", "jdoe@xy12345"} {
+		if _, err := ParseDSN(bad); err == nil {
+			t.Errorf("expected error for DSN %q", bad)
+		}
+	}
+}
+
+func TestDriver_Query(t *testing.T) {
+	var gotBindings map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Bindings map[string]any `json:"bindings"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		gotBindings = body.Bindings
+		w.Write([]byte(`{
+			"code": "090001",
+			"resultSetMetaData": {"rowType": [
+				{"name": "ID", "type": "fixed", "scale": 0, "precision": 38},
+				{"name": "NAME", "type": "text", "nullable": true},
+				{"name": "CREATED_AT", "type": "timestamp_ntz", "scale": 9}
+			]},
+			"data": [["1", "widget", "1710498645.000000000"], ["2", null, "1710498646.000000000"]]
+		}`))
+	}))
+	defer srv.Close()
+
+	privPath, pubPath := writeKeyPair(t, t.TempDir())
+	dsn := "jdoe@xy12345/SALES/PUBLIC?baseURL=" + url.QueryEscape(srv.URL) +
+		"&privateKeyPath=" + url.QueryEscape(privPath) + "&publicKeyPath=" + url.QueryEscape(pubPath)
+
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id, name, created_at FROM products WHERE id > ?", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil || types[0].DatabaseTypeName() != "FIXED" || types[2].ScanType().String() != "time.Time" {
+		t.Errorf("unexpected column types: %v (err %v)", types, err)
+	}
+
+	var count int
+	for rows.Next() {
+		var (
+			id      int64
+			name    sql.NullString
+			created time.Time
+		)
+		if err := rows.Scan(&id, &name, &created); err != nil {
+			t.Fatalf("scan failed: %v", err)
+		}
+		count++
+		if id == 2 && name.Valid {
+			t.Errorf("expected NULL name for id 2")
+		}
+		if id == 1 && (name.String != "widget" || created.Unix() != 1710498645) {
+			t.Errorf("unexpected row 1: %v %v", name, created)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 rows, got %d", count)
+	}
+	if gotBindings["1"] == nil {
+		t.Errorf("expected positional binding to be sent, got %v", gotBindings)
+	}
+}
+
+func TestDriver_QueryWaitsForLongRunningStatement(t *testing.T) {
+	var polls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		polls++
+		w.Write([]byte(`{
+			"code": "090001",
+			"statementHandle": "h1",
+			"resultSetMetaData": {"rowType": [{"name": "N", "type": "number", "scale": 0}]},
+			"data": [["7"]]
+		}`))
+	}))
+	defer srv.Close()
+
+	privPath, _ := writeKeyPair(t, t.TempDir())
+	db, err := sql.Open(DriverName, "jdoe@xy12345?baseURL="+url.QueryEscape(srv.URL)+"&privateKeyPath="+url.QueryEscape(privPath))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("CALL long_running()")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer rows.Close()
+
+	types, err := rows.ColumnTypes()
+	if err != nil || types[0].ScanType().String() != "int64" {
+		t.Errorf("expected NUMBER to scan as int64, got %v (err %v)", types, err)
+	}
+	var n int64
+	if !rows.Next() || rows.Scan(&n) != nil || n != 7 {
+		t.Errorf("expected 7, got %d (err %v)", n, rows.Err())
+	}
+	if polls != 1 {
+		t.Errorf("expected 1 poll, got %d", polls)
+	}
+}
@@ -0,0 +1,85 @@
+package sqldriver
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+// ParseDSN converts a DSN of the form
+//
+//	user@account/database/schema?warehouse=WH&role=ROLE&privateKeyPath=/path/rsa_key.p8
+//
+// into a client Config. Key files are read eagerly. Other supported
+// parameters: publicKeyPath (derived from the private key when omitted),
+// passphrase, region, baseURL, privateLink (true/false) and
+// expireAfter / httpTimeout (Go durations).
+func ParseDSN(dsn string) (snowapi.Config, error) {
+	var cfg snowapi.Config
+
+	dsn = strings.TrimPrefix(dsn, DriverName+"://")
+	u, err := url.Parse("//" + dsn)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid DSN: %w", err)
+	}
+	if u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return cfg, fmt.Errorf("invalid DSN: expected user@account")
+	}
+
+	cfg.User = u.User.Username()
+	cfg.Account = u.Host
+
+	path := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(path) > 2 {
+		return cfg, fmt.Errorf("invalid DSN: expected at most /database/schema, got %q", u.Path)
+	}
+	if len(path) > 0 {
+		cfg.Database = path[0]
+	}
+	if len(path) > 1 {
+		cfg.Schema = path[1]
+	}
+
+	q := u.Query()
+	cfg.Warehouse = q.Get("warehouse")
+	cfg.Role = q.Get("role")
+	cfg.Region = q.Get("region")
+	cfg.BaseURL = q.Get("baseURL")
+	cfg.PrivateLink = q.Get("privateLink") == "true"
+	if p := q.Get("passphrase"); p != "" {
+		cfg.Passphrase = []byte(p)
+	}
+
+	for name, dst := range map[string]*time.Duration{
+		"expireAfter": &cfg.ExpireAfter,
+		"httpTimeout": &cfg.HTTPTimeout,
+	} {
+		if v := q.Get(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return cfg, fmt.Errorf("invalid DSN: %s: %w", name, err)
+			}
+			*dst = d
+		}
+	}
+
+	privPath := q.Get("privateKeyPath")
+	if privPath == "" {
+		return cfg, fmt.Errorf("invalid DSN: privateKeyPath is required")
+	}
+	if cfg.PrivateKey, err = os.ReadFile(privPath); err != nil {
+		return cfg, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	if pubPath := q.Get("publicKeyPath"); pubPath != "" {
+		if cfg.PublicKey, err = os.ReadFile(pubPath); err != nil {
+			return cfg, fmt.Errorf("failed to read public key: %w", err)
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,116 @@
+package sqldriver
+
+import (
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/vjain20/gosnowapi/snowapi"
+)
+
+// rows adapts a snowapi.RowIterator, converting values by column type.
+type rows struct {
+	it   *snowapi.RowIterator
+	cols []snowapi.ColumnMeta
+}
+
+var (
+	_ driver.RowsColumnTypeDatabaseTypeName = (*rows)(nil)
+	_ driver.RowsColumnTypeScanType         = (*rows)(nil)
+	_ driver.RowsColumnTypeNullable         = (*rows)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*rows)(nil)
+)
+
+func newRows(it *snowapi.RowIterator) *rows {
+	return &rows{it: it, cols: it.Columns()}
+}
+
+func (r *rows) Columns() []string {
+	names := make([]string, len(r.cols))
+	for i, col := range r.cols {
+		names[i] = col.Name
+	}
+	return names
+}
+
+func (r *rows) Close() error { return r.it.Close() }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if !r.it.Next() {
+		if err := r.it.Err(); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+
+	// Scan into a pointer-to-pointer per column so NULLs stay nil.
+	ptrs := make([]any, len(r.cols))
+	for i, col := range r.cols {
+		ptrs[i] = reflect.New(reflect.PointerTo(scanType(col))).Interface()
+	}
+	if err := r.it.Scan(ptrs...); err != nil {
+		return err
+	}
+
+	for i := range dest {
+		v := reflect.ValueOf(ptrs[i]).Elem()
+		if v.IsNil() {
+			dest[i] = nil
+			continue
+		}
+		dest[i] = v.Elem().Interface()
+	}
+	return nil
+}
+
+func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
+	return strings.ToUpper(r.cols[index].Type)
+}
+
+func (r *rows) ColumnTypeScanType(index int) reflect.Type {
+	return scanType(r.cols[index])
+}
+
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return r.cols[index].Nullable, true
+}
+
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	col := r.cols[index]
+	if col.Precision == nil || col.Scale == nil {
+		return 0, 0, false
+	}
+	return int64(*col.Precision), int64(*col.Scale), true
+}
+
+var (
+	int64Type   = reflect.TypeOf(int64(0))
+	float64Type = reflect.TypeOf(float64(0))
+	boolType    = reflect.TypeOf(false)
+	timeType    = reflect.TypeOf(time.Time{})
+	bytesType   = reflect.TypeOf([]byte(nil))
+	stringType  = reflect.TypeOf("")
+)
+
+// scanType maps a Snowflake column to the Go type its values decode to.
+func scanType(col snowapi.ColumnMeta) reflect.Type {
+	switch strings.ToLower(col.Type) {
+	case "fixed", "number":
+		if col.Scale == nil || *col.Scale == 0 {
+			return int64Type
+		}
+		return float64Type
+	case "real":
+		return float64Type
+	case "boolean":
+		return boolType
+	case "date", "time", "timestamp_ntz", "timestamp_ltz", "timestamp_tz":
+		return timeType
+	case "binary":
+		return bytesType
+	default:
+		return stringType
+	}
+}
@@ -0,0 +1,89 @@
+package snowapi
+
+// SQLState is a five-character ANSI SQLSTATE reported with a Snowflake
+// error. The first two characters are its class; see Class and the Is*
+// helpers for common conditions.
+type SQLState string
+
+// sqlStateClasses describes the SQLSTATE classes Snowflake reports.
+var sqlStateClasses = map[string]string{
+	"00": "successful completion",
+	"01": "warning",
+	"02": "no data",
+	"08": "connection exception",
+	"0A": "feature not supported",
+	"21": "cardinality violation",
+	"22": "data exception",
+	"23": "integrity constraint violation",
+	"25": "invalid transaction state",
+	"28": "invalid authorization specification",
+	"40": "transaction rollback",
+	"42": "syntax error or access rule violation",
+	"53": "insufficient resources",
+	"54": "program limit exceeded",
+	"57": "operator intervention",
+	"58": "system error",
+	"P0": "procedure error",
+	"XX": "internal error",
+}
+
+// Class returns the two-character class of s, or "" if s is malformed.
+func (s SQLState) Class() string {
+	if len(s) != 5 {
+		return ""
+	}
+	return string(s[:2])
+}
+
+// Description returns a short description of the class of s, or "" for an
+// unknown class.
+func (s SQLState) Description() string {
+	return sqlStateClasses[s.Class()]
+}
+
+// IsSyntaxError reports a statement that could not be compiled (42000,
+// Snowflake's state for SQL compilation errors, or 42601).
+func (s SQLState) IsSyntaxError() bool {
+	return s == "42000" || s == "42601"
+}
+
+// IsConstraintViolation reports an integrity constraint violation (class
+// 23), such as a NULL in a NOT NULL column (23502). Snowflake only enforces
+// NOT NULL, and unique constraints on hybrid tables.
+func (s SQLState) IsConstraintViolation() bool {
+	return s.Class() == "23"
+}
+
+// IsUniqueViolation reports a duplicate key in a unique constraint (23505).
+func (s SQLState) IsUniqueViolation() bool {
+	return s == "23505"
+}
+
+// IsDataError reports an invalid value, such as a failed numeric conversion
+// (22018) or a value out of range (22003).
+func (s SQLState) IsDataError() bool {
+	return s.Class() == "22"
+}
+
+// IsUndefinedObject reports a missing table or view (42S02), column (42S22)
+// or other object (42704).
+func (s SQLState) IsUndefinedObject() bool {
+	return s == sqlStateNotFound || s == "42S22" || s == "42704"
+}
+
+// IsInsufficientPrivileges reports an access control error (42501); see
+// PrivilegeError.
+func (s SQLState) IsInsufficientPrivileges() bool {
+	return s == sqlStateInsufficientPrivileges
+}
+
+// IsCanceled reports a statement canceled by a user or a timeout (57014).
+func (s SQLState) IsCanceled() bool {
+	return s == sqlStateCanceled
+}
+
+// IsTransactionRollback reports a transaction rolled back, for example on a
+// deadlock or lock timeout (class 40); the statement may be retried.
+func (s SQLState) IsTransactionRollback() bool {
+	return s.Class() == "40"
+}
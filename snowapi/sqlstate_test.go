@@ -0,0 +1,60 @@
+package snowapi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestSQLState(t *testing.T) {
+	tests := []struct {
+		state       SQLState
+		class       string
+		description string
+		check       func(SQLState) bool
+	}{
+		{"42000", "42", "syntax error or access rule violation", SQLState.IsSyntaxError},
+		{"42601", "42", "syntax error or access rule violation", SQLState.IsSyntaxError},
+		{"23505", "23", "integrity constraint violation", SQLState.IsUniqueViolation},
+		{"23502", "23", "integrity constraint violation", SQLState.IsConstraintViolation},
+		{"22018", "22", "data exception", SQLState.IsDataError},
+		{"42S02", "42", "syntax error or access rule violation", SQLState.IsUndefinedObject},
+		{"42501", "42", "syntax error or access rule violation", SQLState.IsInsufficientPrivileges},
+		{"57014", "57", "operator intervention", SQLState.IsCanceled},
+		{"40001", "40", "transaction rollback", SQLState.IsTransactionRollback},
+	}
+	for _, tt := range tests {
+		if got := tt.state.Class(); got != tt.class {
+			t.Errorf("%s: Class() = %q, want %q", tt.state, got, tt.class)
+		}
+		if got := tt.state.Description(); got != tt.description {
+			t.Errorf("%s: Description() = %q, want %q", tt.state, got, tt.description)
+		}
+		if !tt.check(tt.state) {
+			t.Errorf("%s: expected its helper to match", tt.state)
+		}
+	}
+
+	if SQLState("42S02").IsSyntaxError() || SQLState("23502").IsUniqueViolation() || SQLState("42000").IsConstraintViolation() {
+		t.Error("expected helpers not to match other states")
+	}
+	if s := SQLState(""); s.Class() != "" || s.Description() != "" || s.IsConstraintViolation() {
+		t.Error("expected an empty state to match nothing")
+	}
+}
+
+func TestAPIError_SQLState(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"100072","sqlState":"23502","message":"NULL result in a non-nullable column"}`))
+	})
+
+	_, err := client.Execute("INSERT INTO t VALUES (NULL)", false, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if !apiErr.SQLState.IsConstraintViolation() || apiErr.SQLState.IsSyntaxError() {
+		t.Errorf("unexpected classification of %s", apiErr.SQLState)
+	}
+}
@@ -0,0 +1,284 @@
+package snowapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileFormat describes how staged files are parsed. Type is the format type
+// (CSV, JSON, PARQUET, ...) and Options holds format options such as
+// FIELD_DELIMITER or SKIP_HEADER. Option values may be strings (quoted),
+// integers, floats, bools or []string (rendered as a parenthesized list, as
+// NULL_IF expects). Name refers to a named file format instead and excludes
+// Type and Options.
+type FileFormat struct {
+	Type    string
+	Options map[string]any
+	Name    string
+}
+
+// CopyOptions controls CopyInto. Files and Pattern restrict which staged
+// files are loaded; OnError is the ON_ERROR action (CONTINUE, SKIP_FILE,
+// ABORT_STATEMENT); Purge removes files once loaded.
+type CopyOptions struct {
+	FileFormat        *FileFormat
+	Files             []string
+	Pattern           string
+	OnError           string
+	Purge             bool
+	MatchByColumnName string // CASE_SENSITIVE, CASE_INSENSITIVE or NONE
+}
+
+// CopyFileResult is the COPY INTO outcome for one staged file.
+type CopyFileResult struct {
+	File           string
+	Status         string
+	RowsParsed     int64
+	RowsLoaded     int64
+	ErrorsSeen     int64
+	FirstError     string
+	FirstErrorLine int64
+}
+
+// CopyResult summarizes a COPY INTO: one entry per file considered, plus the
+// rows loaded and errors seen across all of them. Files is empty when no
+// files matched.
+type CopyResult struct {
+	Files      []CopyFileResult
+	RowsLoaded int64
+	ErrorsSeen int64
+}
+
+// CreateTempStage creates a temporary internal stage, dropped at the end of
+// the session, if it does not exist. format may be nil.
+func (c *Client) CreateTempStage(stage string, format *FileFormat) error {
+	return c.CreateTempStageContext(context.Background(), stage, format)
+}
+
+// CreateTempStageContext is like CreateTempStage but honors ctx.
+func (c *Client) CreateTempStageContext(ctx context.Context, stage string, format *FileFormat) error {
+	stmt, err := createStageStatement(stage, format)
+	if err != nil {
+		return err
+	}
+	_, err = c.queryCompleted(ctx, stmt)
+	return err
+}
+
+// CopyInto loads files from stage into table with COPY INTO and returns the
+// per-file results. Files are uploaded to the stage separately (PUT is not
+// supported by the SQL API). table and stage are quoted as in
+// QuoteIdentifier, so they are case-sensitive. opts may be nil.
+func (c *Client) CopyInto(table, stage string, opts *CopyOptions) (*CopyResult, error) {
+	return c.CopyIntoContext(context.Background(), table, stage, opts)
+}
+
+// CopyIntoContext is like CopyInto but honors ctx.
+func (c *Client) CopyIntoContext(ctx context.Context, table, stage string, opts *CopyOptions) (*CopyResult, error) {
+	stmt, err := copyIntoStatement(table, stage, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.queryCompleted(ctx, stmt)
+	if err != nil {
+		return nil, err
+	}
+	return parseCopyResult(resp)
+}
+
+// createStageStatement renders CREATE TEMPORARY STAGE for stage.
+func createStageStatement(stage string, format *FileFormat) (string, error) {
+	name, err := QuoteIdentifier(stage)
+	if err != nil {
+		return "", fmt.Errorf("stage: %w", err)
+	}
+	stmt := "CREATE TEMPORARY STAGE IF NOT EXISTS " + name
+	if format != nil {
+		clause, err := format.clause()
+		if err != nil {
+			return "", err
+		}
+		stmt += " FILE_FORMAT = " + clause
+	}
+	return stmt, nil
+}
+
+// copyIntoStatement renders COPY INTO table FROM @stage with opts applied.
+func copyIntoStatement(table, stage string, opts *CopyOptions) (string, error) {
+	target, err := QuoteIdentifier(table)
+	if err != nil {
+		return "", fmt.Errorf("table: %w", err)
+	}
+	source, err := QuoteIdentifier(stage)
+	if err != nil {
+		return "", fmt.Errorf("stage: %w", err)
+	}
+	stmt := "COPY INTO " + target + " FROM @" + source
+	if opts == nil {
+		return stmt, nil
+	}
+
+	if len(opts.Files) > 0 {
+		files := make([]string, len(opts.Files))
+		for i, f := range opts.Files {
+			files[i] = quoteString(f)
+		}
+		stmt += " FILES = (" + strings.Join(files, ", ") + ")"
+	}
+	if opts.Pattern != "" {
+		stmt += " PATTERN = " + quoteString(opts.Pattern)
+	}
+	if opts.FileFormat != nil {
+		clause, err := opts.FileFormat.clause()
+		if err != nil {
+			return "", err
+		}
+		stmt += " FILE_FORMAT = " + clause
+	}
+	if opts.OnError != "" {
+		if !isKeyword(opts.OnError) {
+			return "", fmt.Errorf("invalid ON_ERROR action %q", opts.OnError)
+		}
+		stmt += " ON_ERROR = " + strings.ToUpper(opts.OnError)
+	}
+	if opts.MatchByColumnName != "" {
+		if !isKeyword(opts.MatchByColumnName) {
+			return "", fmt.Errorf("invalid MATCH_BY_COLUMN_NAME %q", opts.MatchByColumnName)
+		}
+		stmt += " MATCH_BY_COLUMN_NAME = " + strings.ToUpper(opts.MatchByColumnName)
+	}
+	if opts.Purge {
+		stmt += " PURGE = TRUE"
+	}
+	return stmt, nil
+}
+
+// clause renders f as a parenthesized FILE_FORMAT value. Options are sorted
+// by name so the statement is deterministic.
+func (f *FileFormat) clause() (string, error) {
+	if f.Name != "" {
+		if f.Type != "" || len(f.Options) > 0 {
+			return "", fmt.Errorf("file format: Name excludes Type and Options")
+		}
+		name, err := QuoteIdentifier(f.Name)
+		if err != nil {
+			return "", fmt.Errorf("file format: %w", err)
+		}
+		return "(FORMAT_NAME = " + name + ")", nil
+	}
+
+	var parts []string
+	if f.Type != "" {
+		if !isKeyword(f.Type) {
+			return "", fmt.Errorf("invalid file format type %q", f.Type)
+		}
+		parts = append(parts, "TYPE = "+strings.ToUpper(f.Type))
+	}
+	keys := make([]string, 0, len(f.Options))
+	for k := range f.Options {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return strings.ToUpper(keys[i]) < strings.ToUpper(keys[j]) })
+	for _, k := range keys {
+		if !isKeyword(k) {
+			return "", fmt.Errorf("invalid file format option %q", k)
+		}
+		v, err := formatOptionValue(f.Options[k])
+		if err != nil {
+			return "", fmt.Errorf("file format option %s: %w", k, err)
+		}
+		parts = append(parts, strings.ToUpper(k)+" = "+v)
+	}
+	return "(" + strings.Join(parts, " ") + ")", nil
+}
+
+// formatOptionValue renders a file format option value.
+func formatOptionValue(v any) (string, error) {
+	if list, ok := v.([]string); ok {
+		quoted := make([]string, len(list))
+		for i, s := range list {
+			quoted[i] = quoteString(s)
+		}
+		return "(" + strings.Join(quoted, ", ") + ")", nil
+	}
+	switch v.(type) {
+	case nil, []byte, Decimal:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
+	return QuoteLiteral(v)
+}
+
+// isKeyword reports whether s is a bare SQL keyword or option name: letters,
+// digits and underscores, not starting with a digit.
+func isKeyword(s string) bool {
+	if s == "" || !isPlaceholderStart(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if !isPlaceholderChar(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseCopyResult reads the per-file rows COPY INTO returns. A statement that
+// matched no files returns a single status column and yields no entries.
+func parseCopyResult(resp *QueryResponse) (*CopyResult, error) {
+	index := make(map[string]int)
+	for i, col := range resp.Columns() {
+		index[strings.ToLower(col.Name)] = i
+	}
+	result := &CopyResult{}
+	if _, ok := index["file"]; !ok {
+		return result, nil
+	}
+
+	for n, row := range resp.Data {
+		text := func(col string) string {
+			i, ok := index[col]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			s, _ := row[i].(string)
+			return s
+		}
+		number := func(col string) (int64, error) {
+			s := text(col)
+			if s == "" {
+				return 0, nil
+			}
+			v, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("row %d: invalid %s %q", n, col, s)
+			}
+			return v, nil
+		}
+
+		f := CopyFileResult{
+			File:       text("file"),
+			Status:     text("status"),
+			FirstError: text("first_error"),
+		}
+		var err error
+		if f.RowsParsed, err = number("rows_parsed"); err != nil {
+			return nil, err
+		}
+		if f.RowsLoaded, err = number("rows_loaded"); err != nil {
+			return nil, err
+		}
+		if f.ErrorsSeen, err = number("errors_seen"); err != nil {
+			return nil, err
+		}
+		if f.FirstErrorLine, err = number("first_error_line"); err != nil {
+			return nil, err
+		}
+		result.Files = append(result.Files, f)
+		result.RowsLoaded += f.RowsLoaded
+		result.ErrorsSeen += f.ErrorsSeen
+	}
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package snowapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateStageStatement(t *testing.T) {
+	got, err := createStageStatement("ANALYTICS.RAW.LOAD_STAGE", &FileFormat{
+		Type: "csv",
+		Options: map[string]any{
+			"SKIP_HEADER":                    1,
+			"FIELD_DELIMITER":                "|",
+			"FIELD_OPTIONALLY_ENCLOSED_BY":   `"`,
+			"NULL_IF":                        []string{"", "NULL"},
+			"error_on_column_count_mismatch": false,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `CREATE TEMPORARY STAGE IF NOT EXISTS "ANALYTICS"."RAW"."LOAD_STAGE" FILE_FORMAT = ` +
+		`(TYPE = CSV ERROR_ON_COLUMN_COUNT_MISMATCH = FALSE FIELD_DELIMITER = '|' ` +
+		`FIELD_OPTIONALLY_ENCLOSED_BY = '"' NULL_IF = ('', 'NULL') SKIP_HEADER = 1)`
+	if got != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	got, err = createStageStatement("tmp", nil)
+	if err != nil || got != `CREATE TEMPORARY STAGE IF NOT EXISTS "tmp"` {
+		t.Errorf("unexpected statement %q (err %v)", got, err)
+	}
+}
+
+func TestCopyIntoStatement(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *CopyOptions
+		want string
+	}{
+		{"no options", nil, `COPY INTO "DB"."EVENTS" FROM @"LOAD_STAGE"`},
+		{
+			"all options",
+			&CopyOptions{
+				FileFormat:        &FileFormat{Type: "JSON", Options: map[string]any{"STRIP_OUTER_ARRAY": true}},
+				Files:             []string{"a.json.gz", "it's.json.gz"},
+				Pattern:           `.*\.json\.gz`,
+				OnError:           "continue",
+				Purge:             true,
+				MatchByColumnName: "CASE_INSENSITIVE",
+			},
+			`COPY INTO "DB"."EVENTS" FROM @"LOAD_STAGE" FILES = ('a.json.gz', 'it''s.json.gz') ` +
+				`PATTERN = '.*\\.json\\.gz' FILE_FORMAT = (TYPE = JSON STRIP_OUTER_ARRAY = TRUE) ` +
+				`ON_ERROR = CONTINUE MATCH_BY_COLUMN_NAME = CASE_INSENSITIVE PURGE = TRUE`,
+		},
+		{
+			"named format",
+			&CopyOptions{FileFormat: &FileFormat{Name: "DB.PUBLIC.MY_CSV"}},
+			`COPY INTO "DB"."EVENTS" FROM @"LOAD_STAGE" FILE_FORMAT = (FORMAT_NAME = "DB"."PUBLIC"."MY_CSV")`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := copyIntoStatement("DB.EVENTS", "LOAD_STAGE", tt.opts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got  %s\nwant %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyIntoStatement_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		table string
+		opts  *CopyOptions
+	}{
+		{"empty table", "", nil},
+		{"empty identifier part", "DB..T", nil},
+		{"option injection", "T", &CopyOptions{FileFormat: &FileFormat{Options: map[string]any{"X = 1) --": 1}}}},
+		{"type injection", "T", &CopyOptions{FileFormat: &FileFormat{Type: "CSV) PURGE = TRUE --"}}},
+		{"on error injection", "T", &CopyOptions{OnError: "CONTINUE; DROP TABLE T"}},
+		{"name with type", "T", &CopyOptions{FileFormat: &FileFormat{Name: "F", Type: "CSV"}}},
+		{"unsupported value", "T", &CopyOptions{FileFormat: &FileFormat{Options: map[string]any{"NULL_IF": nil}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if stmt, err := copyIntoStatement(tt.table, "S", tt.opts); err == nil {
+				t.Errorf("expected an error, got %q", stmt)
+			}
+		})
+	}
+}
+
+func TestCopyInto_Result(t *testing.T) {
+	var got QueryRequest
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+		w.Write([]byte(`{
+			"code": "090001",
+			"resultSetMetaData": {"rowType": [
+				{"name": "file", "type": "text"},
+				{"name": "status", "type": "text"},
+				{"name": "rows_parsed", "type": "fixed"},
+				{"name": "rows_loaded", "type": "fixed"},
+				{"name": "error_limit", "type": "fixed"},
+				{"name": "errors_seen", "type": "fixed"},
+				{"name": "first_error", "type": "text"},
+				{"name": "first_error_line", "type": "fixed"}
+			]},
+			"data": [
+				["load_stage/a.csv", "LOADED", "10", "10", "1", "0", null, null],
+				["load_stage/b.csv", "PARTIALLY_LOADED", "5", "4", "5", "1", "Numeric value 'x' is not recognized", "3"]
+			]
+		}`))
+	})
+
+	res, err := client.CopyInto("EVENTS", "LOAD_STAGE", &CopyOptions{OnError: "CONTINUE"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(got.Statement, `COPY INTO "EVENTS" FROM @"LOAD_STAGE"`) {
+		t.Errorf("unexpected statement %q", got.Statement)
+	}
+	if res.RowsLoaded != 14 || res.ErrorsSeen != 1 || len(res.Files) != 2 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+	want := CopyFileResult{
+		File:           "load_stage/b.csv",
+		Status:         "PARTIALLY_LOADED",
+		RowsParsed:     5,
+		RowsLoaded:     4,
+		ErrorsSeen:     1,
+		FirstError:     "Numeric value 'x' is not recognized",
+		FirstErrorLine: 3,
+	}
+	if res.Files[1] != want {
+		t.Errorf("file 2 = %+v, want %+v", res.Files[1], want)
+	}
+}
+
+func TestCopyInto_NoFiles(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"code": "090001",
+			"resultSetMetaData": {"rowType": [{"name": "status", "type": "text"}]},
+			"data": [["Copy executed with 0 files processed."]]
+		}`))
+	})
+
+	res, err := client.CopyInto("EVENTS", "LOAD_STAGE", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(res.Files) != 0 || res.RowsLoaded != 0 {
+		t.Errorf("unexpected result: %+v", res)
+	}
+}
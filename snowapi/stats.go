@@ -0,0 +1,37 @@
+package snowapi
+
+import "sync/atomic"
+
+// Stats is a point-in-time snapshot of client activity, suitable for
+// exporting as gauges (in-flight counts) and counters (totals).
+type Stats struct {
+	InFlightExecutes  int64 // statement submissions awaiting a response
+	ActiveWaits       int64 // WaitUntilComplete polling loops in progress
+	PartitionsFetched int64 // result partitions fetched since the client was created
+
+	Circuit CircuitState // circuit breaker state; CircuitClosed when disabled
+}
+
+// clientStats holds the live counters behind Stats.
+type clientStats struct {
+	inFlightExecutes  atomic.Int64
+	activeWaits       atomic.Int64
+	partitionsFetched atomic.Int64
+}
+
+// Stats returns a snapshot of the client's activity counters.
+func (c *Client) Stats() Stats {
+	return Stats{
+		InFlightExecutes:  c.stats.inFlightExecutes.Load(),
+		ActiveWaits:       c.stats.activeWaits.Load(),
+		PartitionsFetched: c.stats.partitionsFetched.Load(),
+		Circuit:           c.breaker.currentState(),
+	}
+}
+
+// track increments gauge and returns a func that decrements it, for use
+// with defer so a panic cannot leak the count.
+func track(gauge *atomic.Int64) func() {
+	gauge.Add(1)
+	return func() { gauge.Add(-1) }
+}
@@ -0,0 +1,52 @@
+package snowapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	var client *Client
+	var during Stats
+	polls := 0
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			during = client.Stats()
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		polls++
+		if polls == 1 {
+			during.ActiveWaits = client.Stats().ActiveWaits
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		w.Write([]byte(`{"code":"090001","statementHandle":"h1","resultSetMetaData":{"partitionInfo":[{"rowCount":1},{"rowCount":1}]},"data":[["1"]]}`))
+	})
+	client.config.PollStrategy = PollStrategy{Initial: time.Millisecond, Max: time.Millisecond}
+
+	if _, err := client.ExecuteAndWait("SELECT 1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if during.InFlightExecutes != 1 || during.ActiveWaits != 1 {
+		t.Errorf("unexpected in-flight stats: %+v", during)
+	}
+	if got := client.Stats(); got.InFlightExecutes != 0 || got.ActiveWaits != 0 || got.PartitionsFetched != 2 {
+		t.Errorf("unexpected final stats: %+v", got)
+	}
+}
+
+func TestTrack_ReleasesOnPanic(t *testing.T) {
+	var c Client
+	func() {
+		defer func() { recover() }()
+		defer track(&c.stats.activeWaits)()
+		panic("boom")
+	}()
+	if n := c.Stats().ActiveWaits; n != 0 {
+		t.Errorf("expected gauge to be released, got %d", n)
+	}
+}
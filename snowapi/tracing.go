@@ -0,0 +1,78 @@
+package snowapi
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span attribute keys set when Config.Tracer is configured.
+const (
+	attrStatementHandle = "snowflake.statement_handle"
+	attrRequestID       = "snowflake.request_id"
+	attrAsync           = "snowflake.async"
+	attrPartition       = "snowflake.partition"
+	attrPartitionCount  = "snowflake.partition_count"
+	attrRowCount        = "snowflake.row_count"
+	attrHTTPStatus      = "http.status_code"
+)
+
+// span wraps an OpenTelemetry span. startSpan returns a nil *span when no
+// tracer is configured, and every method is a no-op on nil, so untraced
+// clients pay only a nil check.
+type span struct {
+	s trace.Span
+}
+
+// startSpan starts a client span named name as a child of any span in ctx.
+func (c *Client) startSpan(ctx context.Context, name string) (context.Context, *span) {
+	if c.config.Tracer == nil {
+		return ctx, nil
+	}
+	ctx, s := c.config.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, &span{s: s}
+}
+
+func (s *span) setString(key, value string) {
+	if s == nil || value == "" {
+		return
+	}
+	s.s.SetAttributes(attribute.String(key, value))
+}
+
+func (s *span) setInt(key string, value int) {
+	if s == nil {
+		return
+	}
+	s.s.SetAttributes(attribute.Int(key, value))
+}
+
+func (s *span) setBool(key string, value bool) {
+	if s == nil {
+		return
+	}
+	s.s.SetAttributes(attribute.Bool(key, value))
+}
+
+// finish records resp's handle, request ID and result shape plus err, then
+// ends the span.
+func (s *span) finish(resp *QueryResponse, err error) {
+	if s == nil {
+		return
+	}
+	if resp != nil {
+		s.setString(attrStatementHandle, resp.StatementHandle)
+		s.setString(attrRequestID, resp.RequestID)
+		if resp.Code != "333334" {
+			s.setInt(attrPartitionCount, partitionCount(resp.ResultSetMetaData.PartitionInfo))
+			s.setInt(attrRowCount, len(resp.Data))
+		}
+	}
+	if err != nil {
+		s.s.RecordError(err)
+		s.s.SetStatus(codes.Error, err.Error())
+	}
+	s.s.End()
+}
@@ -0,0 +1,144 @@
+package snowapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// recordingTracer records started spans; parents are tracked through the
+// context so nesting can be checked without the OpenTelemetry SDK.
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+type recordedSpan struct {
+	trace.Span // no-op defaults
+	name       string
+	parent     *recordedSpan
+	attrs      map[attribute.Key]attribute.Value
+	errs       []error
+	status     codes.Code
+	ended      bool
+}
+
+type spanKey struct{}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*recordedSpan)
+	s := &recordedSpan{
+		Span:   trace.SpanFromContext(context.Background()),
+		name:   name,
+		parent: parent,
+		attrs:  map[attribute.Key]attribute.Value{},
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+func (s *recordedSpan) RecordError(err error, _ ...trace.EventOption) { s.errs = append(s.errs, err) }
+func (s *recordedSpan) SetStatus(code codes.Code, _ string)           { s.status = code }
+func (s *recordedSpan) End(...trace.SpanEndOption)                    { s.ended = true }
+
+func (t *recordingTracer) names() string {
+	var names []string
+	for _, s := range t.spans {
+		names = append(names, s.name)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestTracing_ExecuteAndWait(t *testing.T) {
+	polls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"code":"333334","statementHandle":"h1"}`))
+			return
+		}
+		polls++
+		w.Write([]byte(`{"code":"090001","statementHandle":"h1","resultSetMetaData":{"partitionInfo":[{"rowCount":2}]},"data":[["1"],["2"]]}`))
+	})
+	tracer := &recordingTracer{}
+	client.config.Tracer = tracer
+
+	if _, err := client.ExecuteAndWait("SELECT 1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := tracer.names(); got != "snowflake.execute,snowflake.wait,snowflake.poll" {
+		t.Fatalf("unexpected spans: %s", got)
+	}
+
+	exec, wait, poll := tracer.spans[0], tracer.spans[1], tracer.spans[2]
+	if exec.attrs[attrStatementHandle].AsString() != "h1" || exec.attrs[attrRequestID].AsString() == "" {
+		t.Errorf("unexpected execute attributes: %v", exec.attrs)
+	}
+	if exec.attrs[attrAsync].AsBool() {
+		t.Error("expected async=false")
+	}
+	if _, ok := exec.attrs[attrRowCount]; ok {
+		t.Error("expected no row count for a still-running statement")
+	}
+	if poll.parent != wait {
+		t.Error("expected poll span to be a child of the wait span")
+	}
+	if wait.attrs[attrRowCount].AsInt64() != 2 || wait.attrs[attrPartitionCount].AsInt64() != 1 {
+		t.Errorf("unexpected wait attributes: %v", wait.attrs)
+	}
+	for _, s := range tracer.spans {
+		if !s.ended {
+			t.Errorf("span %s not ended", s.name)
+		}
+	}
+}
+
+func TestTracing_RecordsErrorsAndParent(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"no such statement"}`))
+	})
+	tracer := &recordingTracer{}
+	client.config.Tracer = tracer
+
+	ctx, parent := tracer.Start(context.Background(), "caller")
+	if err := client.CancelContext(ctx, "h1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, _, err := client.PollContext(ctx, "h1", 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, s := range tracer.spans[1:] {
+		if s.parent != parent {
+			t.Errorf("span %s is not a child of the caller span", s.name)
+		}
+		if s.status != codes.Error || len(s.errs) != 1 {
+			t.Errorf("span %s: expected a recorded error, got status %v errs %v", s.name, s.status, s.errs)
+		}
+	}
+}
+
+func TestTracing_DisabledWithoutTracer(t *testing.T) {
+	var c Client
+	ctx := context.Background()
+	got, s := c.startSpan(ctx, "snowflake.execute")
+	if got != ctx || s != nil {
+		t.Fatal("expected no span without a tracer")
+	}
+	s.setString(attrRequestID, "x")
+	s.finish(&QueryResponse{}, nil)
+}
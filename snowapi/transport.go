@@ -0,0 +1,106 @@
+package snowapi
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Connection pool defaults for the default HTTP client. Every request goes
+// to the one account host, so the per-host idle limit matters most; Go's
+// default of 2 makes concurrent callers above that re-handshake TLS.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 16
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// newTransport returns the transport of the default HTTP client: Go's
+// default transport with the pool sized per Config and TLS per
+// Config.TLSConfig (at least TLS 1.2 when unset). Requests go through
+// Config.ProxyURL when set, or else the proxy named by the HTTPS_PROXY,
+// HTTP_PROXY and NO_PROXY environment variables.
+func newTransport(cfg Config) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSConfig != nil {
+		t.TLSClientConfig = cfg.TLSConfig.Clone()
+	}
+	t.Proxy = http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxy, err := parseProxyURL(cfg.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		t.Proxy = http.ProxyURL(proxy)
+	}
+	t.MaxIdleConns = DefaultMaxIdleConns
+	if cfg.MaxIdleConns > 0 {
+		t.MaxIdleConns = cfg.MaxIdleConns
+	}
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	if cfg.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+	if cfg.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+	return t, nil
+}
+
+// parseProxyURL validates a Config.ProxyURL. Errors never echo the URL, as
+// it may carry proxy credentials.
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ProxyURL: %w", errors.Unwrap(err))
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid ProxyURL: scheme must be http, https or socks5, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid ProxyURL: missing host")
+	}
+	return u, nil
+}
+
+// Warmup resolves the Snowflake host and completes a TLS handshake ahead of
+// the first query, leaving the connection idle in the pool for it. It sends
+// one unauthenticated HEAD request to the host root, carrying the
+// User-Agent and Config.Headers of real requests, and ignores the status, so
+// only network and TLS failures are reported. The connection is kept for
+// IdleConnTimeout.
+func (c *Client) Warmup(ctx context.Context) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return fmt.Errorf("warmup failed: %w", err)
+	}
+	target := (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, target, nil)
+	if err != nil {
+		return fmt.Errorf("warmup failed: %w", err)
+	}
+	c.setUnauthenticatedHeaders(req)
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("warmup failed: %w", err)
+	}
+	// Drain so the connection returns to the pool.
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	c.logger.Debugf("snowapi: warmed up connection to %s in %v (status %d)", u.Host, time.Since(start), resp.StatusCode)
+	return nil
+}
@@ -1,11 +1,31 @@
 package snowapi
 
+import "fmt"
+
 // QueryRequest represents the request body for executing a SQL statement.
 type QueryRequest struct {
 	Statement         string               `json:"statement"`
 	Timeout           int                  `json:"timeout,omitempty"`
 	ResultSetMetaData *ResultSetMetaConfig `json:"resultSetMetaData,omitempty"`
+	Bindings          map[string]Binding   `json:"bindings,omitempty"`
+	Database          string               `json:"database,omitempty"`
+	Schema            string               `json:"schema,omitempty"`
+	Warehouse         string               `json:"warehouse,omitempty"`
+	Role              string               `json:"role,omitempty"`
+	Parameters        map[string]string    `json:"parameters,omitempty"`
 	// Future options: Async, RequestID, etc.
+
+	encoded []byte // pre-marshaled body, e.g. from ExecuteReader
+}
+
+// Binding is a single bind variable, keyed by its 1-based position.
+type Binding struct {
+	Type  string  `json:"type"`
+	Value *string `json:"value"` // nil binds SQL NULL
+
+	// Values, when set, replaces Value with an array binding: the statement
+	// runs once per element (see BatchInsert).
+	Values []*string `json:"-"`
 }
 
 // ResultSetMetaConfig defines the format of metadata in response.
@@ -20,9 +40,20 @@ type QueryResponse struct {
 	Code               string            `json:"code"`
 	StatementStatusURL string            `json:"statementStatusUrl"`
 	StatementHandle    string            `json:"statementHandle"`
+	StatementHandles   []string          `json:"statementHandles,omitempty"` // multi-statement sub-statements
 	SQLState           string            `json:"sqlState"`
 	Message            string            `json:"message"`
-	CreatedOn          int64             `json:"createdOn"`
+	CreatedOn          int64             `json:"createdOn"` // epoch milliseconds; see CreatedOnTime
+	DMLStats           *DMLStats         `json:"stats,omitempty"`
+
+	// RequestID is the requestId echoed by Snowflake, or else the one the
+	// client sent with the request that produced this response.
+	RequestID string `json:"requestId,omitempty"`
+
+	// HTTPStatus is the status of the response this was decoded from. A
+	// success response without a body (e.g. 204) yields an otherwise empty
+	// QueryResponse.
+	HTTPStatus int `json:"-"`
 }
 
 // ResultSetMetaData describes the metadata for returned data.
@@ -55,6 +86,14 @@ type PartitionMeta struct {
 	CompressedSize   *int `json:"compressedSize,omitempty"`
 }
 
+// DMLStats holds the row counts Snowflake reports for DML statements.
+type DMLStats struct {
+	RowsInserted         int64 `json:"numRowsInserted"`
+	RowsUpdated          int64 `json:"numRowsUpdated"`
+	RowsDeleted          int64 `json:"numRowsDeleted"`
+	DuplicateRowsUpdated int64 `json:"numDuplicateRowsUpdated"`
+}
+
 // QueryErrorResponse captures error payloads (e.g. 422, 408)
 type QueryErrorResponse struct {
 	Code            string `json:"code"`
@@ -63,7 +102,70 @@ type QueryErrorResponse struct {
 	StatementHandle string `json:"statementHandle,omitempty"`
 }
 
+// RequestOptions adjusts a single statement submission. NewRequestOptions
+// builds one with a generated RequestID.
 type RequestOptions struct {
-	RequestID string // Optional UUID for deduplication
-	Retry     *bool  // Optional: default true if RequestID is set, otherwise false
+	RequestID string // Optional: UUID identifying the submission
+
+	// DedupRetry controls the retry query parameter sent with RequestID.
+	// With retry=true, Snowflake treats a submission whose requestId it has
+	// already seen as a resubmission and returns the original statement
+	// instead of running it again, which makes resending safe; the client
+	// then also retries transient HTTP failures per Config.Retry. Set it to
+	// false to send a requestId (e.g. for tracing) without deduplication.
+	// Defaults to true when RequestID is set; setting it to true without a
+	// RequestID is an error.
+	DedupRetry *bool
+
+	// Deprecated: use DedupRetry, which takes precedence when both are set.
+	Retry *bool
+
+	// Nullable controls the nullable query parameter. The default (true)
+	// returns SQL NULL as JSON null; false returns the string "null".
+	Nullable *bool
+
+	// StatementTimeout is the Snowflake statement-level timeout in seconds,
+	// sent in the request body. It is separate from the transport-level
+	// Config.HTTPTimeout. Defaults to 60 when unset.
+	StatementTimeout int
+
+	// ResultFormat overrides Config.ResultFormat ("json" or "jsonv2").
+	ResultFormat string
+
+	// Session context overrides; empty values fall back to Config.
+	Database  string
+	Schema    string
+	Warehouse string
+	Role      string
+
+	// Parameters are session parameters for this statement, overriding
+	// Config.Parameters key by key; see Config.Parameters.
+	Parameters map[string]string
+
+	// MinimalMetadata drops ResultSetMetaData.RowType from the response
+	// Execute returns, so large schemas are not retained alongside the
+	// data. The SQL API has no way to omit the metadata from the response
+	// itself, so the request is unchanged and the payload is not smaller.
+	// Without RowType, values cannot be converted by type (Scan, ToRecords,
+	// DecodeValue); use it only when reading raw Data.
+	MinimalMetadata bool
+}
+
+// dedupRetry resolves DedupRetry (or the deprecated Retry) against
+// RequestID.
+func (o *RequestOptions) dedupRetry() (bool, error) {
+	if o == nil {
+		return false, nil
+	}
+	flag := o.DedupRetry
+	if flag == nil {
+		flag = o.Retry
+	}
+	if o.RequestID == "" {
+		if o.DedupRetry != nil && *o.DedupRetry {
+			return false, fmt.Errorf("RequestOptions.DedupRetry requires a RequestID")
+		}
+		return false, nil
+	}
+	return flag == nil || *flag, nil
 }
@@ -0,0 +1,16 @@
+package snowapi
+
+// Version is the library version reported in the default User-Agent.
+const Version = "0.1.0"
+
+// defaultUserAgent is sent on every request unless Config.UserAgent
+// prefixes it.
+const defaultUserAgent = "gosnowapi/" + Version
+
+// userAgent returns the User-Agent header for requests from c.
+func (c *Client) userAgent() string {
+	if c.config.UserAgent != "" {
+		return c.config.UserAgent + " " + defaultUserAgent
+	}
+	return defaultUserAgent
+}